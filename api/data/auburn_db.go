@@ -11,11 +11,14 @@ import (
 
 // AuburnOfficer is the object model for LPD officers
 type AuburnOfficer struct {
-	Date      string `json:"date,omitempty"`
-	Badge     string `json:"badge,omitempty"`
-	Title     string `json:"title,omitempty"`
-	FirstName string `json:"first_name,omitempty"`
-	LastName  string `json:"last_name,omitempty"`
+	Date            string `json:"date,omitempty"`
+	Badge           string `json:"badge,omitempty"`
+	BadgeNormalized string `json:"badge_normalized,omitempty"`
+	Title           string `json:"title,omitempty"`
+	CanonicalRank   string `json:"canonical_rank,omitempty"`
+	FirstName       string `json:"first_name,omitempty"`
+	LastName        string `json:"last_name,omitempty"`
+	NameMatch
 }
 
 // auburnOfficer is an internal intermediary between the returned SQL rows data
@@ -28,6 +31,11 @@ type auburnOfficer struct {
 	LastName  nulls.String
 }
 
+// auburnFilterColumns maps the filters supported by Auburn strict searches onto their columns
+var auburnFilterColumns = map[string]string{
+	"title": "o.title",
+}
+
 // AuburnOfficerMetadata retrieves metadata describing the AuburnOfficer struct
 func (c *Client) AuburnOfficerMetadata() *DepartmentMetadata {
 	var date time.Time
@@ -64,6 +72,10 @@ func (c *Client) AuburnOfficerMetadata() *DepartmentMetadata {
 				"FieldName": "title",
 				"Label":     "Title",
 			},
+			{
+				"FieldName": "canonical_rank",
+				"Label":     "Canonical Rank",
+			},
 		},
 		LastAvailableRosterDate: date.Format("2006-01-02"),
 		Name:                    "Auburn PD",
@@ -71,20 +83,26 @@ func (c *Client) AuburnOfficerMetadata() *DepartmentMetadata {
 		SearchRoutes: map[string]*SearchRouteMetadata{
 			"exact": {
 				Path:        "/auburn/officer",
-				QueryParams: []string{"badge", "first_name", "last_name"},
+				QueryParams: []string{"q", "badge", "badge_mode", "first_name", "last_name", "nicknames", "title", "mode", "rank"},
 			},
 			"fuzzy": {
 				Path:        "/auburn/officer/search",
-				QueryParams: []string{"first_name", "last_name"},
+				QueryParams: []string{"q", "first_name", "last_name", "nicknames", "mode", "min_score", "threshold", "sort", "rank"},
+			},
+			"autocomplete": {
+				Path:        "/auburn/officer/autocomplete",
+				QueryParams: []string{"prefix", "limit"},
 			},
 		},
 	}
 }
 
-// AuburnGetOfficerByBadge returns an officer by their badge.
-func (c *Client) AuburnGetOfficerByBadge(badge string) ([]*AuburnOfficer, error) {
+// AuburnGetOfficerByBadge returns the roster entries matching a badge search, ranked by how
+// close their badge is to the badge searched.
+func (c *Client) AuburnGetOfficerByBadge(badge BadgeSearch) ([]*AuburnOfficer, error) {
+	condition, distance, args := badge.match("o.badge_normalized")
 	rows, err := c.pool.Query(context.Background(),
-		`
+		fmt.Sprintf(`
 			SELECT
 				o.date,
 				o.badge,
@@ -92,9 +110,13 @@ func (c *Client) AuburnGetOfficerByBadge(badge string) ([]*AuburnOfficer, error)
 				o.last_name,
 				o.title
 			FROM auburn_officers o
-			WHERE o.badge = $1;
-		`,
-		badge,
+			WHERE %s
+			ORDER BY
+				%s,
+				o.badge,
+				o.date DESC;
+		`, condition, distance),
+		args...,
 	)
 	if err != nil {
 		return nil, err
@@ -105,9 +127,10 @@ func (c *Client) AuburnGetOfficerByBadge(badge string) ([]*AuburnOfficer, error)
 }
 
 // AuburnSearchOfficerByName returns an officer by their first or last name.
-func (c *Client) AuburnSearchOfficerByName(firstName, lastName string) ([]*AuburnOfficer, error) {
+func (c *Client) AuburnSearchOfficerByName(firstName, lastName string, nicknames []string, filters Filters) ([]*AuburnOfficer, error) {
+	conditions, args := filters.conditions(auburnFilterColumns, []interface{}{firstName, lastName, nicknames})
 	rows, err := c.pool.Query(context.Background(),
-		`
+		fmt.Sprintf(`
 			SELECT
 				o.date,
 				o.badge,
@@ -115,103 +138,87 @@ func (c *Client) AuburnSearchOfficerByName(firstName, lastName string) ([]*Aubur
 				o.last_name,
 				o.title
 			FROM auburn_officers o
-			WHERE LOWER(o.first_name) LIKE LOWER($1) AND LOWER(o.last_name) LIKE LOWER($2)
+			WHERE (%s OR o.first_name_normalized = ANY($3)) AND %s%s
 			ORDER BY 
 				o.last_name,
 				o.first_name;
-		`,
-		firstName,
-		lastName,
+		`, filters.matchName("o.first_name", 1), filters.matchName("o.last_name", 2), conditions),
+		args...,
 	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	return auburnMarshalOfficerRows(rows)
+	return auburnMarshalOfficerRows(rows, nicknames...)
 }
 
 // AuburnFuzzySearchByName returns an list of officers by their full name using fuzzy matching.
-// Entries are sorted by similarity to the full name in descending order.
-func (c *Client) AuburnFuzzySearchByName(name string) ([]*AuburnOfficer, error) {
-	rows, err := c.pool.Query(context.Background(),
-		`
-			SELECT
-				o.date,
-				o.badge,
-				o.first_name,
-				o.last_name,
-				o.title
-			FROM auburn_officers o
-			WHERE LOWER(first_name || ' ' || last_name) % LOWER($1)
-			ORDER BY
-				SIMILARITY(LOWER(o.first_name || ' ' || o.last_name), LOWER($1)) DESC;
-		`,
-		name,
-	)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	return auburnMarshalOfficerRows(rows)
+// Entries are sorted by how well they match the full name in descending order,
+// or by roster date first when sorting by recency.
+func (c *Client) AuburnFuzzySearchByName(name string, opts FuzzyOptions) ([]*AuburnOfficer, error) {
+	return c.auburnFuzzySearch(fuzzyFullName, name, opts)
 }
 
 // AuburnFuzzySearchByFirstName returns an list of officers by their first name using fuzzy matching.
-// Entries are sorted by similarity to the full name in descending order.
-func (c *Client) AuburnFuzzySearchByFirstName(firstName string) ([]*AuburnOfficer, error) {
+// Entries are sorted by how well they match the first name in descending order,
+// or by roster date first when sorting by recency.
+func (c *Client) AuburnFuzzySearchByFirstName(firstName string, opts FuzzyOptions) ([]*AuburnOfficer, error) {
+	return c.auburnFuzzySearch(fuzzyFirstName, firstName, opts)
+}
+
+// AuburnFuzzySearchByLastName returns an list of officers by their last name using fuzzy matching.
+// Entries are sorted by how well they match the last name in descending order,
+// or by roster date first when sorting by recency.
+func (c *Client) AuburnFuzzySearchByLastName(lastName string, opts FuzzyOptions) ([]*AuburnOfficer, error) {
+	return c.auburnFuzzySearch(fuzzyLastName, lastName, opts)
+}
+
+// auburnFuzzySearch returns the officers whose name matches value, using the matching
+// requested through opts.
+func (c *Client) auburnFuzzySearch(name fuzzyName, value string, opts FuzzyOptions) ([]*AuburnOfficer, error) {
+	match := name.match(value, opts)
 	rows, err := c.pool.Query(context.Background(),
-		`
+		fmt.Sprintf(`
 			SELECT
 				o.date,
 				o.badge,
 				o.first_name,
 				o.last_name,
-				o.title
+				o.title,
+				%[2]s match_score,
+				%[3]s matched_on
 			FROM auburn_officers o
-			WHERE LOWER(first_name) % LOWER($1)
-			ORDER BY
-				SIMILARITY(LOWER(o.first_name), LOWER($1)) DESC;
-		`,
-		firstName,
+			WHERE %[1]s
+			ORDER BY %[4]s;
+		`, match.condition, match.score, match.matchedOn, opts.orderBy("o.date")),
+		match.args...,
 	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	return auburnMarshalOfficerRows(rows)
-}
-
-// AuburnFuzzySearchByLastName returns an list of officers by their last name using fuzzy matching.
-// Entries are sorted by similarity to the full name in descending order.
-func (c *Client) AuburnFuzzySearchByLastName(lastName string) ([]*AuburnOfficer, error) {
-	rows, err := c.pool.Query(context.Background(),
-		`
-			SELECT
-				o.date,
-				o.badge,
-				o.first_name,
-				o.last_name,
-				o.title
-			FROM auburn_officers o
-			WHERE LOWER(last_name) % LOWER($1)
-			ORDER BY
-				SIMILARITY(LOWER(o.last_name), LOWER($1)) DESC;
-		`,
-		lastName,
-	)
+	fuzzy := &fuzzyRows{Rows: rows}
+	officers, err := auburnMarshalOfficerRows(fuzzy, opts.Nicknames...)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
+	for i, ofc := range officers {
+		ofc.MatchScore, ofc.MatchedOn = fuzzy.matches[i].MatchScore, fuzzy.matches[i].MatchedOn
+	}
+	return officers, nil
+}
 
-	return auburnMarshalOfficerRows(rows)
+// AuburnHeadcountByDate returns the number of officers on each available roster date,
+// optionally grouped by title.
+func (c *Client) AuburnHeadcountByDate(groupBy string) ([]*HeadcountStat, error) {
+	return c.headcountByDate("auburn_officers", groupBy)
 }
 
 // auburnMarshalOfficerRows takes SQL return objects and marshals them onto the
 // AuburnOfficer object for return as JSON by the API.
-func auburnMarshalOfficerRows(rows pgx.Rows) ([]*AuburnOfficer, error) {
+func auburnMarshalOfficerRows(rows pgx.Rows, nicknames ...string) ([]*AuburnOfficer, error) {
 	officers := []*AuburnOfficer{}
 	for rows.Next() {
 		ofc := auburnOfficer{}
@@ -230,12 +237,21 @@ func auburnMarshalOfficerRows(rows pgx.Rows) ([]*AuburnOfficer, error) {
 		returnOfficer := AuburnOfficer{
 			ofc.Date.Format("2006-01-02"),
 			ofc.Badge.String,
+			NormalizeBadge(ofc.Badge.String),
 			ofc.Title.String,
+			CanonicalRank(ofc.Title.String),
 			ofc.FirstName.String,
 			ofc.LastName.String,
+			NameMatch{MatchedNickname: matchedNickname(ofc.FirstName.String, nicknames)},
 		}
 
 		officers = append(officers, &returnOfficer)
 	}
 	return officers, nil
 }
+
+// AuburnAutocomplete suggests up to limit officer names starting with prefix, along with each
+// officer's badge and title.
+func (c *Client) AuburnAutocomplete(prefix string, limit int) ([]*OfficerSuggestion, error) {
+	return c.autocomplete(namedAutocompleteSource("auburn_officers", "o.badge", "o.title", "o.date"), prefix, limit)
+}
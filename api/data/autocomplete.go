@@ -0,0 +1,118 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+)
+
+// Limits on the number of suggestions returned by an autocomplete search
+const (
+	AutocompleteLimitDefault = 10
+	AutocompleteLimitMax     = 25
+)
+
+// autocompleteTimeout bounds how long an autocomplete search may take, so that a slow
+// suggestion is dropped rather than holding up the next keystroke's request.
+const autocompleteTimeout = 500 * time.Millisecond
+
+// OfficerSuggestion is a single name suggested by an autocomplete search
+type OfficerSuggestion struct {
+	Name  string `json:"name"`
+	Badge string `json:"badge,omitempty"`
+	Title string `json:"title,omitempty"`
+}
+
+// autocompleteSource describes the roster table an autocomplete search suggests names from.
+// Expressions the table doesn't have a column for are left empty.
+type autocompleteSource struct {
+	table    string
+	name     string
+	lastName string
+	badge    string
+	title    string
+	date     string
+}
+
+// namedAutocompleteSource describes the roster table of a department storing first and last
+// names separately.
+func namedAutocompleteSource(table, badge, title, date string) autocompleteSource {
+	return autocompleteSource{
+		table:    table,
+		name:     "o.first_name || ' ' || o.last_name",
+		lastName: "o.last_name",
+		badge:    badge,
+		title:    title,
+		date:     date,
+	}
+}
+
+// autocomplete suggests up to limit names from source starting with prefix, a lowercase LIKE
+// pattern matched against either the officer's full name or their last name. Names matching on the
+// full name are ranked first, then names are suggested alphabetically. Each officer is only
+// suggested once, along with the badge and title of their latest roster entry.
+func (c *Client) autocomplete(source autocompleteSource, prefix string, limit int) ([]*OfficerSuggestion, error) {
+	badge, title, date := "NULL", "NULL", "NULL"
+	if source.badge != "" {
+		badge = source.badge
+	}
+	if source.title != "" {
+		title = source.title
+	}
+	if source.date != "" {
+		date = source.date
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), autocompleteTimeout)
+	defer cancel()
+
+	rows, err := c.pool.Query(ctx,
+		fmt.Sprintf(`
+			WITH suggestions AS (
+				SELECT DISTINCT ON (LOWER(%[2]s), %[4]s)
+					%[2]s AS name,
+					%[4]s::TEXT AS badge,
+					%[5]s::TEXT AS title
+				FROM %[1]s o
+				WHERE LOWER(%[2]s) LIKE $1
+					OR LOWER(%[3]s) LIKE $1
+				ORDER BY
+					LOWER(%[2]s),
+					%[4]s,
+					%[6]s DESC NULLS LAST
+			)
+			SELECT
+				s.name,
+				s.badge,
+				s.title
+			FROM suggestions s
+			ORDER BY
+				LOWER(s.name) LIKE $1 DESC,
+				s.name,
+				s.badge
+			LIMIT $2;
+		`, source.table, source.name, source.lastName, badge, title, date),
+		prefix, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	suggestions := []*OfficerSuggestion{}
+	for rows.Next() {
+		var name, badge, title nulls.String
+		err = rows.Scan(&name, &badge, &title)
+		if err != nil {
+			return nil, err
+		}
+		suggestions = append(suggestions, &OfficerSuggestion{
+			Name:  name.String,
+			Badge: badge.String,
+			Title: title.String,
+		})
+	}
+	return suggestions, rows.Err()
+}
@@ -0,0 +1,109 @@
+package data
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Badge search modes, selecting how badges are matched
+const (
+	BadgeModeExact      = "exact"
+	BadgeModePartial    = "partial"
+	BadgeModeConfusable = "confusable"
+)
+
+// BadgeModes lists the supported badge search modes, the default mode first
+var BadgeModes = []string{BadgeModeExact, BadgeModePartial, BadgeModeConfusable}
+
+// badgeConfusables maps the characters commonly misread when badges are read from photos
+// onto the characters they are mistaken for.
+var badgeConfusables = map[rune]string{
+	'0': "O8DQ",
+	'O': "08DQ",
+	'D': "0O",
+	'Q': "0O",
+	'8': "0B",
+	'B': "8",
+	'1': "7IL",
+	'7': "1",
+	'I': "1L",
+	'L': "1I",
+	'5': "S",
+	'S': "5",
+	'2': "Z",
+	'Z': "2",
+	'6': "G",
+	'G': "6",
+}
+
+// badgePrefix and badgeLeadingZeros match the prefixes and leading zeros stripped from badges.
+// badgePatternLeadingZeros matches the leading zeros stripped from badge patterns, which keep
+// a zero directly followed by a wildcard.
+var (
+	badgePrefix              = regexp.MustCompile(`^(BADGE|DPSST|NO\.|#)+`)
+	badgeLeadingZeros        = regexp.MustCompile(`^0+(.)`)
+	badgePatternLeadingZeros = regexp.MustCompile(`^0+([^*?])`)
+)
+
+// NormalizeBadge uppercases badge and strips its whitespace, leading "#", "BADGE", "DPSST" or
+// "NO." prefixes and leading zeros, so "06248", "6248" and "# 6248" all normalize to "6248".
+// It mirrors the normalize_badge SQL function badges are stored and compared with.
+func NormalizeBadge(badge string) string {
+	badge = strings.ToUpper(strings.Join(strings.Fields(badge), ""))
+	badge = badgePrefix.ReplaceAllString(badge, "")
+	return badgeLeadingZeros.ReplaceAllString(badge, "$1")
+}
+
+// NormalizeBadgePattern normalizes a badge searched as a wildcard pattern the same way as
+// NormalizeBadge, except that a leading zero directly followed by a wildcard is kept, so "0*"
+// finds the badges starting with 0 rather than every badge.
+func NormalizeBadgePattern(badge string) string {
+	badge = strings.ToUpper(strings.Join(strings.Fields(badge), ""))
+	badge = badgePrefix.ReplaceAllString(badge, "")
+	return badgePatternLeadingZeros.ReplaceAllString(badge, "$1")
+}
+
+// BadgeSearch describes the badges matched by a badge lookup
+type BadgeSearch struct {
+	// Badge is the badge searched for, as entered
+	Badge string
+	// Pattern is the LIKE pattern badges are matched against in the exact and partial modes,
+	// built from the badge normalized through NormalizeBadgePattern
+	Pattern string
+	// Mode selects how badges are matched: against Pattern, or in the confusable mode against
+	// Badge and every badge one confusable character substitution away from it
+	Mode string
+}
+
+// match renders the SQL condition selecting the badges matching the search, and the
+// expression measuring their edit distance to the badge searched, which results are ranked
+// by. Badges are compared normalized: column holds the normalized badges (see
+// normalize_badge) and the badges searched are normalized the same way. Pattern is already
+// normalized and is matched as is, since normalizing it in SQL would strip the zeros in
+// front of its wildcards. The condition and distance refer to the query arguments $1 and
+// $2 returned.
+func (b BadgeSearch) match(column string) (string, string, []interface{}) {
+	distance := fmt.Sprintf("levenshtein(%s, normalize_badge($2))", column)
+	if b.Mode == BadgeModeConfusable {
+		condition := fmt.Sprintf("%s = ANY(SELECT normalize_badge(b) FROM UNNEST($1::TEXT[]) b)", column)
+		return condition, distance, []interface{}{confusableBadges(NormalizeBadge(b.Badge)), b.Badge}
+	}
+	return fmt.Sprintf("%s LIKE $1", column), distance, []interface{}{b.Pattern, b.Badge}
+}
+
+// confusableBadges returns badge, uppercased, along with every badge differing from it by a
+// single confusable character substitution.
+func confusableBadges(badge string) []string {
+	characters := []rune(strings.ToUpper(badge))
+	badges := []string{string(characters)}
+	for i, c := range characters {
+		for _, confusable := range badgeConfusables[c] {
+			variant := make([]rune, len(characters))
+			copy(variant, characters)
+			variant[i] = confusable
+			badges = append(badges, string(variant))
+		}
+	}
+	return badges
+}
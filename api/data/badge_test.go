@@ -0,0 +1,89 @@
+package data
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeBadge(t *testing.T) {
+	for _, tt := range []struct {
+		badge      string
+		normalized string
+	}{
+		{"6248", "6248"},
+		{"06248", "6248"},
+		{"# 6248", "6248"},
+		{"Badge 0041", "41"},
+		{"DPSST54321", "54321"},
+		{"No. 12a", "12A"},
+		{"0", "0"},
+		{"000", "0"},
+		{"", ""},
+	} {
+		t.Run(tt.badge, func(t *testing.T) {
+			if normalized := NormalizeBadge(tt.badge); normalized != tt.normalized {
+				t.Errorf("NormalizeBadge(%q) = %q, want %q", tt.badge, normalized, tt.normalized)
+			}
+		})
+	}
+}
+
+func TestNormalizeBadgePattern(t *testing.T) {
+	for _, tt := range []struct {
+		badge      string
+		normalized string
+	}{
+		{"06248", "6248"},
+		{"#062?8", "62?8"},
+		{"062*", "62*"},
+		{"0*", "0*"},
+		{"00?", "0?"},
+		{"*48", "*48"},
+	} {
+		t.Run(tt.badge, func(t *testing.T) {
+			if normalized := NormalizeBadgePattern(tt.badge); normalized != tt.normalized {
+				t.Errorf("NormalizeBadgePattern(%q) = %q, want %q", tt.badge, normalized, tt.normalized)
+			}
+		})
+	}
+}
+
+func TestConfusableBadges(t *testing.T) {
+	badges := confusableBadges("51")
+	want := []string{"51", "S1", "57", "5I", "5L"}
+	if !reflect.DeepEqual(badges, want) {
+		t.Errorf("confusableBadges(%q) = %v, want %v", "51", badges, want)
+	}
+}
+
+func TestBadgeSearchMatch(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		search    BadgeSearch
+		condition string
+		args      []interface{}
+	}{
+		{
+			"Exact",
+			BadgeSearch{Badge: "0*", Pattern: "0%", Mode: BadgeModeExact},
+			"o.badge_normalized LIKE $1",
+			[]interface{}{"0%", "0*"},
+		},
+		{
+			"Confusable",
+			BadgeSearch{Badge: "05", Mode: BadgeModeConfusable},
+			"o.badge_normalized = ANY(SELECT normalize_badge(b) FROM UNNEST($1::TEXT[]) b)",
+			[]interface{}{[]string{"5", "S"}, "05"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, _, args := tt.search.match("o.badge_normalized")
+			if condition != tt.condition {
+				t.Errorf("condition = %q, want %q", condition, tt.condition)
+			}
+			if !reflect.DeepEqual(args, tt.args) {
+				t.Errorf("args = %v, want %v", args, tt.args)
+			}
+		})
+	}
+}
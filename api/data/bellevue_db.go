@@ -2,6 +2,7 @@ package data
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/gobuffalo/nulls"
 	"github.com/jackc/pgx/v4"
@@ -9,12 +10,15 @@ import (
 
 // BellevueOfficer is the object model for BPD officers
 type BellevueOfficer struct {
-	LastName  string `json:"last_name,omitempty"`
-	FirstName string `json:"first_name,omitempty"`
-	Title     string `json:"title,omitempty"`
-	Unit      string `json:"unit,omitempty"`
-	Notes     string `json:"notes,omitempty"`
-	Badge     string `json:"badge,omitempty"`
+	LastName        string `json:"last_name,omitempty"`
+	FirstName       string `json:"first_name,omitempty"`
+	Title           string `json:"title,omitempty"`
+	CanonicalRank   string `json:"canonical_rank,omitempty"`
+	Unit            string `json:"unit,omitempty"`
+	Notes           string `json:"notes,omitempty"`
+	Badge           string `json:"badge,omitempty"`
+	BadgeNormalized string `json:"badge_normalized,omitempty"`
+	NameMatch
 }
 
 // bellevueOfficer is an internal intermediary between the returned SQL rows data
@@ -28,6 +32,12 @@ type bellevueOfficer struct {
 	Badge     nulls.String
 }
 
+// bellevueFilterColumns maps the filters supported by Bellevue strict searches onto their columns
+var bellevueFilterColumns = map[string]string{
+	"title": "o.title",
+	"unit":  "o.unit",
+}
+
 // BellevueOfficerMetadata retrieves metadata describing the BellevueOfficer struct
 func (c *Client) BellevueOfficerMetadata() *DepartmentMetadata {
 	return &DepartmentMetadata{
@@ -44,6 +54,10 @@ func (c *Client) BellevueOfficerMetadata() *DepartmentMetadata {
 				"FieldName": "title",
 				"Label":     "Officer Title",
 			},
+			{
+				"FieldName": "canonical_rank",
+				"Label":     "Canonical Rank",
+			},
 			{
 				"FieldName": "unit",
 				"Label":     "Officer unit",
@@ -63,20 +77,26 @@ func (c *Client) BellevueOfficerMetadata() *DepartmentMetadata {
 		SearchRoutes: map[string]*SearchRouteMetadata{
 			"exact": {
 				Path:        "/bellevue/officer",
-				QueryParams: []string{"badge", "first_name", "last_name"},
+				QueryParams: []string{"q", "badge", "badge_mode", "first_name", "last_name", "nicknames", "title", "unit", "mode", "rank"},
 			},
 			"fuzzy": {
 				Path:        "/bellevue/officer/search",
-				QueryParams: []string{"first_name", "last_name"},
+				QueryParams: []string{"q", "first_name", "last_name", "nicknames", "mode", "min_score", "threshold", "rank"},
+			},
+			"autocomplete": {
+				Path:        "/bellevue/officer/autocomplete",
+				QueryParams: []string{"prefix", "limit"},
 			},
 		},
 	}
 }
 
-// BellevueSearchOfficerByBadge returns an officer by their first or last name.
-func (c *Client) BellevueSearchOfficerByBadge(badge string) ([]*BellevueOfficer, error) {
+// BellevueSearchOfficerByBadge returns the officers matching a badge search, ranked by how
+// close their badge is to the badge searched.
+func (c *Client) BellevueSearchOfficerByBadge(badge BadgeSearch) ([]*BellevueOfficer, error) {
+	condition, distance, args := badge.match("o.badge_normalized")
 	rows, err := c.pool.Query(context.Background(),
-		`
+		fmt.Sprintf(`
 			SELECT
 				o.last_name,
 				o.first_name,
@@ -85,12 +105,13 @@ func (c *Client) BellevueSearchOfficerByBadge(badge string) ([]*BellevueOfficer,
 				o.notes,
                 o.badge
 			FROM bellevue_officers o
-			WHERE o.badge LIKE $1
+			WHERE %s
 			ORDER BY 
+				%s,
 				o.last_name,
 				o.first_name;
-		`,
-		badge,
+		`, condition, distance),
+		args...,
 	)
 	if err != nil {
 		return nil, err
@@ -101,9 +122,10 @@ func (c *Client) BellevueSearchOfficerByBadge(badge string) ([]*BellevueOfficer,
 }
 
 // BellevueSearchOfficerByName returns an officer by their first or last name.
-func (c *Client) BellevueSearchOfficerByName(firstName, lastName string) ([]*BellevueOfficer, error) {
+func (c *Client) BellevueSearchOfficerByName(firstName, lastName string, nicknames []string, filters Filters) ([]*BellevueOfficer, error) {
+	conditions, args := filters.conditions(bellevueFilterColumns, []interface{}{firstName, lastName, nicknames})
 	rows, err := c.pool.Query(context.Background(),
-		`
+		fmt.Sprintf(`
 			SELECT
 				o.last_name,
 				o.first_name,
@@ -112,108 +134,79 @@ func (c *Client) BellevueSearchOfficerByName(firstName, lastName string) ([]*Bel
 				o.notes,
                 o.badge
 			FROM bellevue_officers o
-			WHERE LOWER(o.first_name) LIKE LOWER($1) AND LOWER(o.last_name) LIKE LOWER($2)
+			WHERE (%s OR o.first_name_normalized = ANY($3)) AND %s%s
 			ORDER BY 
 				o.last_name,
 				o.first_name;
-		`,
-		firstName,
-		lastName,
+		`, filters.matchName("o.first_name", 1), filters.matchName("o.last_name", 2), conditions),
+		args...,
 	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	return bellevueMarshalOfficerRows(rows)
+	return bellevueMarshalOfficerRows(rows, nicknames...)
 }
 
 // BellevueFuzzySearchByName returns an list of officers by their full name using fuzzy matching.
-// Entries are sorted by similarity to the full name in descending order.
-func (c *Client) BellevueFuzzySearchByName(name string) ([]*BellevueOfficer, error) {
-	rows, err := c.pool.Query(context.Background(),
-		`
-			SELECT
-				o.last_name,
-				o.first_name,
-				o.title,
-				o.unit,
-				o.notes,
-                o.badge
-			FROM bellevue_officers o
-			WHERE LOWER(first_name || ' ' || last_name) % LOWER($1)
-			ORDER BY
-				SIMILARITY(LOWER(o.first_name || ' ' || o.last_name), LOWER($1)) DESC;
-		`,
-		name,
-	)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	return bellevueMarshalOfficerRows(rows)
+// Entries are sorted by how well they match the full name in descending order.
+func (c *Client) BellevueFuzzySearchByName(name string, opts FuzzyOptions) ([]*BellevueOfficer, error) {
+	return c.bellevueFuzzySearch(fuzzyFullName, name, opts)
 }
 
 // BellevueFuzzySearchByFirstName returns an list of officers by their first name using fuzzy matching.
-// Entries are sorted by similarity to the full name in descending order.
-func (c *Client) BellevueFuzzySearchByFirstName(firstName string) ([]*BellevueOfficer, error) {
-	rows, err := c.pool.Query(context.Background(),
-		`
-			SELECT
-				o.last_name,
-				o.first_name,
-				o.title,
-				o.unit,
-				o.notes,
-                o.badge
-
-				FROM bellevue_officers o
-			WHERE LOWER(first_name) % LOWER($1)
-			ORDER BY
-				SIMILARITY(LOWER(o.first_name), LOWER($1)) DESC;
-		`,
-		firstName,
-	)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	return bellevueMarshalOfficerRows(rows)
+// Entries are sorted by how well they match the first name in descending order.
+func (c *Client) BellevueFuzzySearchByFirstName(firstName string, opts FuzzyOptions) ([]*BellevueOfficer, error) {
+	return c.bellevueFuzzySearch(fuzzyFirstName, firstName, opts)
 }
 
 // BellevueFuzzySearchByLastName returns an list of officers by their last name using fuzzy matching.
-// Entries are sorted by similarity to the full name in descending order.
-func (c *Client) BellevueFuzzySearchByLastName(lastName string) ([]*BellevueOfficer, error) {
+// Entries are sorted by how well they match the last name in descending order.
+func (c *Client) BellevueFuzzySearchByLastName(lastName string, opts FuzzyOptions) ([]*BellevueOfficer, error) {
+	return c.bellevueFuzzySearch(fuzzyLastName, lastName, opts)
+}
+
+// bellevueFuzzySearch returns the officers whose name matches value, using the matching
+// requested through opts.
+func (c *Client) bellevueFuzzySearch(name fuzzyName, value string, opts FuzzyOptions) ([]*BellevueOfficer, error) {
+	match := name.match(value, opts)
 	rows, err := c.pool.Query(context.Background(),
-		`
+		fmt.Sprintf(`
 			SELECT
 				o.last_name,
 				o.first_name,
 				o.title,
 				o.unit,
 				o.notes,
-                o.badge
-
+				o.badge,
+				%[2]s match_score,
+				%[3]s matched_on
 			FROM bellevue_officers o
-			WHERE LOWER(last_name) % LOWER($1)
-			ORDER BY
-				SIMILARITY(LOWER(o.last_name), LOWER($1)) DESC;
-		`,
-		lastName,
+			WHERE %[1]s
+			ORDER BY match_score DESC;
+		`, match.condition, match.score, match.matchedOn),
+		match.args...,
 	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	return bellevueMarshalOfficerRows(rows)
+	fuzzy := &fuzzyRows{Rows: rows}
+	officers, err := bellevueMarshalOfficerRows(fuzzy, opts.Nicknames...)
+	if err != nil {
+		return nil, err
+	}
+	for i, ofc := range officers {
+		ofc.MatchScore, ofc.MatchedOn = fuzzy.matches[i].MatchScore, fuzzy.matches[i].MatchedOn
+	}
+	return officers, nil
 }
 
 // bellevueMarshalOfficerRows takes SQL return objects and marshals them onto the
 // BellevueOfficer object for return as JSON by the API.
-func bellevueMarshalOfficerRows(rows pgx.Rows) ([]*BellevueOfficer, error) {
+func bellevueMarshalOfficerRows(rows pgx.Rows, nicknames ...string) ([]*BellevueOfficer, error) {
 	officers := []*BellevueOfficer{}
 	for rows.Next() {
 		ofc := bellevueOfficer{}
@@ -234,12 +227,21 @@ func bellevueMarshalOfficerRows(rows pgx.Rows) ([]*BellevueOfficer, error) {
 			ofc.LastName.String,
 			ofc.FirstName.String,
 			ofc.Title.String,
+			CanonicalRank(ofc.Title.String),
 			ofc.Unit.String,
 			ofc.Notes.String,
 			ofc.Badge.String,
+			NormalizeBadge(ofc.Badge.String),
+			NameMatch{MatchedNickname: matchedNickname(ofc.FirstName.String, nicknames)},
 		}
 
 		officers = append(officers, &returnOfficer)
 	}
 	return officers, nil
 }
+
+// BellevueAutocomplete suggests up to limit officer names starting with prefix, along with each
+// officer's badge and title.
+func (c *Client) BellevueAutocomplete(prefix string, limit int) ([]*OfficerSuggestion, error) {
+	return c.autocomplete(namedAutocompleteSource("bellevue_officers", "o.badge", "o.title", ""), prefix, limit)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/jackc/pgx/v4/pgxpool"
 )
@@ -26,73 +27,104 @@ type SearchRouteMetadata struct {
 // DatabaseInterface describes database functions
 type DatabaseInterface interface {
 	SeattleOfficerMetadata() *DepartmentMetadata
-	SeattleGetOfficerByBadge(badge string) ([]*SeattleOfficer, error)
-	SeattleSearchOfficerByName(firstName, lastName string) ([]*SeattleOfficer, error)
-	SeattleFuzzySearchByName(name string) ([]*SeattleOfficer, error)
-	SeattleFuzzySearchByFirstName(firstName string) ([]*SeattleOfficer, error)
-	SeattleFuzzySearchByLastName(lastName string) ([]*SeattleOfficer, error)
+	SeattleGetOfficerByBadge(badge BadgeSearch) ([]*SeattleOfficer, error)
+	SeattleSearchOfficerByName(firstName, lastName string, nicknames []string, filters Filters) ([]*SeattleOfficer, error)
+	SeattleFuzzySearchByName(name string, opts FuzzyOptions) ([]*SeattleOfficer, error)
+	SeattleFuzzySearchByFullName(fullName string, opts FuzzyOptions) ([]*SeattleOfficer, error)
+	SeattleFuzzySearchByFirstName(firstName string, opts FuzzyOptions) ([]*SeattleOfficer, error)
+	SeattleFuzzySearchByLastName(lastName string, opts FuzzyOptions) ([]*SeattleOfficer, error)
+	SeattleAutocomplete(prefix string, limit int) ([]*OfficerSuggestion, error)
 
 	SeattleGetOfficerByBadgeHistorical(badge string) ([]*SeattleOfficer, error)
+	SeattlePrecincts() (*SeattlePrecinctCollection, error)
+	SeattleHeadcountByDate(groupBy string) ([]*HeadcountStat, error)
+	SeattleTitleChanges(from, to time.Time) ([]*SeattleTitleChange, error)
 
 	TacomaOfficerMetadata() *DepartmentMetadata
-	TacomaSearchOfficerByName(firstName, lastName string) ([]*TacomaOfficer, error)
-	TacomaFuzzySearchByName(name string) ([]*TacomaOfficer, error)
-	TacomaFuzzySearchByFirstName(firstName string) ([]*TacomaOfficer, error)
-	TacomaFuzzySearchByLastName(lastName string) ([]*TacomaOfficer, error)
+	TacomaSearchOfficerByName(firstName, lastName string, nicknames []string, filters Filters) ([]*TacomaOfficer, error)
+	TacomaSearchOfficersByFilters(filters Filters) ([]*TacomaOfficer, error)
+	TacomaFuzzySearchByName(name string, opts FuzzyOptions) ([]*TacomaOfficer, error)
+	TacomaFuzzySearchByFirstName(firstName string, opts FuzzyOptions) ([]*TacomaOfficer, error)
+	TacomaFuzzySearchByLastName(lastName string, opts FuzzyOptions) ([]*TacomaOfficer, error)
+	TacomaAutocomplete(prefix string, limit int) ([]*OfficerSuggestion, error)
+	TacomaHeadcountByDate(groupBy string) ([]*HeadcountStat, error)
+	TacomaSalaryStats(groupBy string) ([]*SalaryStat, error)
 
 	PortlandOfficerMetadata() *DepartmentMetadata
+	PortlandSearchOfficersById(id string) ([]*PortlandOfficer, error)
+	PortlandGetOfficerByBadgeHistorical(badge string) ([]*PortlandOfficer, error)
 	PortlandSearchOfficersByBadge(badge string) ([]*PortlandOfficer, error)
 	PortlandSearchOfficersByEmployeeId(employee_id string) ([]*PortlandOfficer, error)
 	PortlandSearchOfficersByHelmetId(helmet_id string) ([]*PortlandOfficer, error)
 	PortlandSearchOfficersByHelmetIdThreeDigit(helmet_id_three_digit string) ([]*PortlandOfficer, error)
-	PortlandSearchOfficersByName(firstName, lastName string) ([]*PortlandOfficer, error)
-	PortlandFuzzySearchByName(name string) ([]*PortlandOfficer, error)
-	PortlandFuzzySearchByFirstName(firstName string) ([]*PortlandOfficer, error)
-	PortlandFuzzySearchByLastName(lastName string) ([]*PortlandOfficer, error)
+	PortlandSearchOfficersByName(firstName, lastName string, nicknames []string, filters Filters) ([]*PortlandOfficer, error)
+	PortlandSearchOfficersByFilters(filters Filters) ([]*PortlandOfficer, error)
+	PortlandSearchRRTOfficers(year int, training bool, filters Filters) ([]*PortlandOfficer, error)
+	PortlandFuzzySearchByName(name string, opts FuzzyOptions) ([]*PortlandOfficer, error)
+	PortlandFuzzySearchByFirstName(firstName string, opts FuzzyOptions) ([]*PortlandOfficer, error)
+	PortlandFuzzySearchByLastName(lastName string, opts FuzzyOptions) ([]*PortlandOfficer, error)
+	PortlandAutocomplete(prefix string, limit int) ([]*OfficerSuggestion, error)
+	PortlandHeadcountByDate(groupBy string) ([]*HeadcountStat, error)
+	PortlandSalaryStats(groupBy string) ([]*SalaryStat, error)
 
 	AuburnOfficerMetadata() *DepartmentMetadata
-	AuburnGetOfficerByBadge(badge string) ([]*AuburnOfficer, error)
-	AuburnSearchOfficerByName(firstName, lastName string) ([]*AuburnOfficer, error)
-	AuburnFuzzySearchByName(name string) ([]*AuburnOfficer, error)
-	AuburnFuzzySearchByFirstName(firstName string) ([]*AuburnOfficer, error)
-	AuburnFuzzySearchByLastName(lastName string) ([]*AuburnOfficer, error)
+	AuburnGetOfficerByBadge(badge BadgeSearch) ([]*AuburnOfficer, error)
+	AuburnSearchOfficerByName(firstName, lastName string, nicknames []string, filters Filters) ([]*AuburnOfficer, error)
+	AuburnFuzzySearchByName(name string, opts FuzzyOptions) ([]*AuburnOfficer, error)
+	AuburnFuzzySearchByFirstName(firstName string, opts FuzzyOptions) ([]*AuburnOfficer, error)
+	AuburnFuzzySearchByLastName(lastName string, opts FuzzyOptions) ([]*AuburnOfficer, error)
+	AuburnAutocomplete(prefix string, limit int) ([]*OfficerSuggestion, error)
+	AuburnHeadcountByDate(groupBy string) ([]*HeadcountStat, error)
 
 	LakewoodOfficerMetadata() *DepartmentMetadata
-	LakewoodSearchOfficerByName(firstName, lastName string) ([]*LakewoodOfficer, error)
-	LakewoodFuzzySearchByName(name string) ([]*LakewoodOfficer, error)
-	LakewoodFuzzySearchByFirstName(firstName string) ([]*LakewoodOfficer, error)
-	LakewoodFuzzySearchByLastName(lastName string) ([]*LakewoodOfficer, error)
+	LakewoodSearchOfficerByName(firstName, lastName string, nicknames []string, filters Filters) ([]*LakewoodOfficer, error)
+	LakewoodFuzzySearchByName(name string, opts FuzzyOptions) ([]*LakewoodOfficer, error)
+	LakewoodFuzzySearchByFirstName(firstName string, opts FuzzyOptions) ([]*LakewoodOfficer, error)
+	LakewoodFuzzySearchByLastName(lastName string, opts FuzzyOptions) ([]*LakewoodOfficer, error)
+	LakewoodAutocomplete(prefix string, limit int) ([]*OfficerSuggestion, error)
+	LakewoodHeadcountByDate(groupBy string) ([]*HeadcountStat, error)
 
 	RentonOfficerMetadata() *DepartmentMetadata
-	RentonSearchOfficerByName(firstName, lastName string) ([]*RentonOfficer, error)
-	RentonFuzzySearchByName(name string) ([]*RentonOfficer, error)
-	RentonFuzzySearchByFirstName(firstName string) ([]*RentonOfficer, error)
-	RentonFuzzySearchByLastName(lastName string) ([]*RentonOfficer, error)
+	RentonSearchOfficerByBadge(badge BadgeSearch) ([]*RentonOfficer, error)
+	RentonSearchOfficerByName(firstName, lastName string, nicknames []string, filters Filters) ([]*RentonOfficer, error)
+	RentonFuzzySearchByName(name string, opts FuzzyOptions) ([]*RentonOfficer, error)
+	RentonFuzzySearchByFullName(fullName string, opts FuzzyOptions) ([]*RentonOfficer, error)
+	RentonFuzzySearchByFirstName(firstName string, opts FuzzyOptions) ([]*RentonOfficer, error)
+	RentonFuzzySearchByLastName(lastName string, opts FuzzyOptions) ([]*RentonOfficer, error)
+	RentonAutocomplete(prefix string, limit int) ([]*OfficerSuggestion, error)
 
 	BellevueOfficerMetadata() *DepartmentMetadata
-	BellevueSearchOfficerByBadge(badge string) ([]*BellevueOfficer, error)
-	BellevueSearchOfficerByName(firstName, lastName string) ([]*BellevueOfficer, error)
-	BellevueFuzzySearchByName(name string) ([]*BellevueOfficer, error)
-	BellevueFuzzySearchByFirstName(firstName string) ([]*BellevueOfficer, error)
-	BellevueFuzzySearchByLastName(lastName string) ([]*BellevueOfficer, error)
+	BellevueSearchOfficerByBadge(badge BadgeSearch) ([]*BellevueOfficer, error)
+	BellevueSearchOfficerByName(firstName, lastName string, nicknames []string, filters Filters) ([]*BellevueOfficer, error)
+	BellevueFuzzySearchByName(name string, opts FuzzyOptions) ([]*BellevueOfficer, error)
+	BellevueFuzzySearchByFirstName(firstName string, opts FuzzyOptions) ([]*BellevueOfficer, error)
+	BellevueFuzzySearchByLastName(lastName string, opts FuzzyOptions) ([]*BellevueOfficer, error)
+	BellevueAutocomplete(prefix string, limit int) ([]*OfficerSuggestion, error)
 
 	PortOfSeattleOfficerMetadata() *DepartmentMetadata
-	PortOfSeattleSearchOfficerByBadge(badge string) ([]*PortOfSeattleOfficer, error)
-	PortOfSeattleSearchOfficerByName(name string) ([]*PortOfSeattleOfficer, error)
-	PortOfSeattleFuzzySearchByName(name string) ([]*PortOfSeattleOfficer, error)
+	PortOfSeattleSearchOfficerByBadge(badge BadgeSearch) ([]*PortOfSeattleOfficer, error)
+	PortOfSeattleSearchOfficerByName(firstName, lastName string, nicknames []string, filters Filters) ([]*PortOfSeattleOfficer, error)
+	PortOfSeattleSearchOfficersByFilters(filters Filters) ([]*PortOfSeattleOfficer, error)
+	PortOfSeattleFuzzySearchByName(name string, opts FuzzyOptions) ([]*PortOfSeattleOfficer, error)
+	PortOfSeattleFuzzySearchByFirstName(firstName string, opts FuzzyOptions) ([]*PortOfSeattleOfficer, error)
+	PortOfSeattleFuzzySearchByLastName(lastName string, opts FuzzyOptions) ([]*PortOfSeattleOfficer, error)
+	PortOfSeattleAutocomplete(prefix string, limit int) ([]*OfficerSuggestion, error)
 
 	ThurstonCountyOfficerMetadata() *DepartmentMetadata
-	ThurstonCountySearchOfficerByName(firstName, lastName string) ([]*ThurstonCountyOfficer, error)
-	ThurstonCountyFuzzySearchByName(name string) ([]*ThurstonCountyOfficer, error)
-	ThurstonCountyFuzzySearchByFirstName(firstName string) ([]*ThurstonCountyOfficer, error)
-	ThurstonCountyFuzzySearchByLastName(lastName string) ([]*ThurstonCountyOfficer, error)
+	ThurstonCountySearchOfficerByName(firstName, lastName string, nicknames []string, filters Filters) ([]*ThurstonCountyOfficer, error)
+	ThurstonCountyFuzzySearchByName(name string, opts FuzzyOptions) ([]*ThurstonCountyOfficer, error)
+	ThurstonCountyFuzzySearchByFirstName(firstName string, opts FuzzyOptions) ([]*ThurstonCountyOfficer, error)
+	ThurstonCountyFuzzySearchByLastName(lastName string, opts FuzzyOptions) ([]*ThurstonCountyOfficer, error)
+	ThurstonCountyAutocomplete(prefix string, limit int) ([]*OfficerSuggestion, error)
 
 	OlympiaOfficerMetadata() *DepartmentMetadata
-	OlympiaGetOfficerByBadge(badge string) ([]*OlympiaOfficer, error)
-	OlympiaSearchOfficerByName(firstName, lastName string) ([]*OlympiaOfficer, error)
-	OlympiaFuzzySearchByName(name string) ([]*OlympiaOfficer, error)
-	OlympiaFuzzySearchByFirstName(firstName string) ([]*OlympiaOfficer, error)
-	OlympiaFuzzySearchByLastName(lastName string) ([]*OlympiaOfficer, error)
+	OlympiaGetOfficerByBadge(badge BadgeSearch) ([]*OlympiaOfficer, error)
+	OlympiaSearchOfficerByName(firstName, lastName string, nicknames []string, filters Filters) ([]*OlympiaOfficer, error)
+	OlympiaFuzzySearchByName(name string, opts FuzzyOptions) ([]*OlympiaOfficer, error)
+	OlympiaFuzzySearchByFirstName(firstName string, opts FuzzyOptions) ([]*OlympiaOfficer, error)
+	OlympiaFuzzySearchByLastName(lastName string, opts FuzzyOptions) ([]*OlympiaOfficer, error)
+	OlympiaAutocomplete(prefix string, limit int) ([]*OfficerSuggestion, error)
+	OlympiaHeadcountByDate(groupBy string) ([]*HeadcountStat, error)
 }
 
 // Client is the client used to connect to the db
@@ -0,0 +1,132 @@
+package data
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Strict search modes, selecting how names and filters are matched
+const (
+	StrictModeWildcard = "wildcard"
+	StrictModeRegex    = "regex"
+)
+
+// StrictModes lists the supported strict search modes, the default mode first
+var StrictModes = []string{StrictModeWildcard, StrictModeRegex}
+
+// MiddleNameFilter is the filter parameter matching officers' middle names, which also
+// match by their initial (see middleNameMatch) outside of regex mode.
+const MiddleNameFilter = "middle_name"
+
+// Filters narrows a strict search down to officers matching every provided pattern.
+type Filters struct {
+	// Patterns are keyed by the query parameter they were provided through
+	Patterns map[string]string
+	// Flags match yes/no columns against the value required, keyed by the query parameter
+	// they were provided through
+	Flags map[string]bool
+	// Ranges bound numeric columns, keyed by the query parameter they were provided through
+	// less its _min or _max suffix
+	Ranges map[string]Range
+	// Regex matches the patterns, along with the names searched, as case insensitive
+	// regular expressions rather than LIKE patterns
+	Regex bool
+}
+
+// Range bounds the values of a numeric filter. A nil bound leaves the range open ended.
+type Range struct {
+	Min *float64
+	Max *float64
+}
+
+// match renders the SQL condition matching column against the pattern bound to the
+// query argument numbered param.
+func (f Filters) match(column string, param int) string {
+	if f.Regex {
+		return fmt.Sprintf("%s ~* $%d", column, param)
+	}
+	return fmt.Sprintf("LOWER(%s) LIKE LOWER($%d)", column, param)
+}
+
+// matchName renders the SQL condition matching the name column against the name pattern
+// bound to the query argument numbered param. Outside of regex mode names are compared in
+// their normalized form, stored alongside them in the column suffixed _normalized, as a
+// whole or word by word (see name_like).
+func (f Filters) matchName(column string, param int) string {
+	if f.Regex {
+		return f.match(column, param)
+	}
+	return fmt.Sprintf("name_like(%s_normalized, $%d)", column, param)
+}
+
+// conditions renders the filters as SQL conditions to append to a WHERE clause. columns
+// maps the filter parameters a department supports onto the columns they apply to, and
+// the filter values are appended to args as the conditions' query arguments. Flags and
+// ranges only match officers whose column was recorded, so a NULL matches neither true
+// nor false and falls in no range.
+func (f Filters) conditions(columns map[string]string, args []interface{}) (string, []interface{}) {
+	params := make([]string, 0, len(f.Patterns))
+	for param := range f.Patterns {
+		if _, ok := columns[param]; ok {
+			params = append(params, param)
+		}
+	}
+	sort.Strings(params)
+
+	var clause strings.Builder
+	for _, param := range params {
+		args = append(args, f.Patterns[param])
+		column := fmt.Sprintf("COALESCE(%s, '')", columns[param])
+		if param == MiddleNameFilter && !f.Regex {
+			clause.WriteString("\n\t\t\tAND " + middleNameMatch(column, len(args)))
+		} else {
+			clause.WriteString("\n\t\t\tAND " + f.match(column, len(args)))
+		}
+	}
+
+	flags := make([]string, 0, len(f.Flags))
+	for param := range f.Flags {
+		if _, ok := columns[param]; ok {
+			flags = append(flags, param)
+		}
+	}
+	sort.Strings(flags)
+
+	for _, param := range flags {
+		args = append(args, f.Flags[param])
+		clause.WriteString(fmt.Sprintf("\n\t\t\tAND %s = $%d", columns[param], len(args)))
+	}
+
+	ranges := make([]string, 0, len(f.Ranges))
+	for param := range f.Ranges {
+		if _, ok := columns[param]; ok {
+			ranges = append(ranges, param)
+		}
+	}
+	sort.Strings(ranges)
+
+	for _, param := range ranges {
+		if min := f.Ranges[param].Min; min != nil {
+			args = append(args, *min)
+			clause.WriteString(fmt.Sprintf("\n\t\t\tAND %s >= $%d", columns[param], len(args)))
+		}
+		if max := f.Ranges[param].Max; max != nil {
+			args = append(args, *max)
+			clause.WriteString(fmt.Sprintf("\n\t\t\tAND %s <= $%d", columns[param], len(args)))
+		}
+	}
+	return clause.String(), args
+}
+
+// middleNameMatch renders the SQL condition matching column against the middle name LIKE
+// pattern bound to the query argument numbered param. Middle names are often recorded, and
+// searched for, by their initial alone, so a pattern made of a single initial matches every
+// middle name starting with it, and a middle name recorded as an initial matches every
+// pattern starting with it.
+func middleNameMatch(column string, param int) string {
+	return fmt.Sprintf(
+		"(LOWER(%[1]s) LIKE LOWER($%[2]d) OR (LOWER($%[2]d) ~ '^[[:alpha:]]\\.?$' AND LOWER(%[1]s) LIKE LOWER(LEFT($%[2]d, 1)) || '%%') OR (%[1]s ~ '^[[:alpha:]]\\.?$' AND LOWER(LEFT(%[1]s, 1)) = LOWER(LEFT($%[2]d, 1))))",
+		column, param,
+	)
+}
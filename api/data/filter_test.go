@@ -0,0 +1,87 @@
+package data
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFiltersConditions(t *testing.T) {
+	min, max := 2010.0, 2015.0
+	columns := map[string]string{
+		"title":       "o.title",
+		"middle_name": "o.middle_name",
+		"rrt":         "o.rrt",
+		"hire_year":   "o.hire_year",
+	}
+	for _, tt := range []struct {
+		name    string
+		filters Filters
+		clause  string
+		args    []interface{}
+	}{
+		{
+			name:    "NoFilters",
+			filters: Filters{},
+			clause:  "",
+			args:    []interface{}{"smith"},
+		},
+		{
+			name:    "Patterns",
+			filters: Filters{Patterns: map[string]string{"title": "%sergeant%", "unit": "%patrol%"}},
+			clause:  "\n\t\t\tAND LOWER(COALESCE(o.title, '')) LIKE LOWER($2)",
+			args:    []interface{}{"smith", "%sergeant%"},
+		},
+		{
+			name:    "Regex",
+			filters: Filters{Patterns: map[string]string{"title": "^Sgt"}, Regex: true},
+			clause:  "\n\t\t\tAND COALESCE(o.title, '') ~* $2",
+			args:    []interface{}{"smith", "^Sgt"},
+		},
+		{
+			name:    "MiddleName",
+			filters: Filters{Patterns: map[string]string{"middle_name": "Z"}},
+			clause:  "\n\t\t\tAND " + middleNameMatch("COALESCE(o.middle_name, '')", 2),
+			args:    []interface{}{"smith", "Z"},
+		},
+		{
+			name:    "RegexMiddleName",
+			filters: Filters{Patterns: map[string]string{"middle_name": "^Z"}, Regex: true},
+			clause:  "\n\t\t\tAND COALESCE(o.middle_name, '') ~* $2",
+			args:    []interface{}{"smith", "^Z"},
+		},
+		{
+			name:    "Flags",
+			filters: Filters{Flags: map[string]bool{"rrt": false, "involved_in_ois_uof": true}},
+			clause:  "\n\t\t\tAND o.rrt = $2",
+			args:    []interface{}{"smith", false},
+		},
+		{
+			name:    "Ranges",
+			filters: Filters{Ranges: map[string]Range{"hire_year": {Min: &min, Max: &max}, "salary": {Min: &min}}},
+			clause:  "\n\t\t\tAND o.hire_year >= $2\n\t\t\tAND o.hire_year <= $3",
+			args:    []interface{}{"smith", 2010.0, 2015.0},
+		},
+		{
+			name:    "OpenEndedRange",
+			filters: Filters{Ranges: map[string]Range{"hire_year": {Max: &max}}},
+			clause:  "\n\t\t\tAND o.hire_year <= $2",
+			args:    []interface{}{"smith", 2015.0},
+		},
+		{
+			name:    "FlagsAndRanges",
+			filters: Filters{Flags: map[string]bool{"rrt": true}, Ranges: map[string]Range{"hire_year": {Min: &min}}},
+			clause:  "\n\t\t\tAND o.rrt = $2\n\t\t\tAND o.hire_year >= $3",
+			args:    []interface{}{"smith", true, 2010.0},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			clause, args := tt.filters.conditions(columns, []interface{}{"smith"})
+			if clause != tt.clause {
+				t.Errorf("clause = %q, want %q", clause, tt.clause)
+			}
+			if !reflect.DeepEqual(args, tt.args) {
+				t.Errorf("args = %v, want %v", args, tt.args)
+			}
+		})
+	}
+}
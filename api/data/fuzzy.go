@@ -0,0 +1,207 @@
+package data
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// Fuzzy search modes supported by the fuzzy name searches
+const (
+	FuzzyModeTrigram  = "trigram"
+	FuzzyModePhonetic = "phonetic"
+)
+
+// FuzzyModes lists the supported fuzzy search modes, the default mode first
+var FuzzyModes = []string{FuzzyModeTrigram, FuzzyModePhonetic}
+
+// Bounds and default of the trigram similarity a name must exceed to match a fuzzy search.
+// The default matches pg_trgm's own similarity threshold.
+const (
+	FuzzyThresholdMin     = 0.1
+	FuzzyThresholdMax     = 0.9
+	FuzzyThresholdDefault = 0.3
+)
+
+// Fuzzy search orderings supported by departments with dated rosters
+const (
+	FuzzySortRelevance = "relevance"
+	FuzzySortRecency   = "recency"
+)
+
+// FuzzySorts lists the supported fuzzy search orderings, the default ordering first
+var FuzzySorts = []string{FuzzySortRelevance, FuzzySortRecency}
+
+// Fuzzy search match sources reported through NameMatch.MatchedOn
+const (
+	MatchedOnFirstName = "first_name"
+	MatchedOnLastName  = "last_name"
+	MatchedOnFullName  = "full_name"
+	MatchedOnNickname  = "nickname"
+	MatchedOnPhonetic  = "phonetic"
+)
+
+// FuzzyOptions tunes how the fuzzy name searches match and rank officers
+type FuzzyOptions struct {
+	Mode string
+	// Nicknames lists alternative first names, such as nicknames, matched alongside the
+	// first name searched.
+	Nicknames []string
+	// MinScore drops the officers whose match score falls below it
+	MinScore float64
+	// Sort orders the results of departments with dated rosters by relevance or recency
+	Sort string
+	// Threshold is the trigram similarity a name must exceed to match, kept within
+	// FuzzyThresholdMin and FuzzyThresholdMax
+	Threshold float64
+	// MiddleName narrows the results of departments recording middle names down to the
+	// officers whose middle name matches this LIKE pattern, or its initial
+	MiddleName string
+}
+
+// threshold returns the requested similarity threshold kept within its bounds, or the
+// default threshold when none was requested.
+func (o FuzzyOptions) threshold() float64 {
+	switch {
+	case o.Threshold == 0:
+		return FuzzyThresholdDefault
+	case o.Threshold < FuzzyThresholdMin:
+		return FuzzyThresholdMin
+	case o.Threshold > FuzzyThresholdMax:
+		return FuzzyThresholdMax
+	}
+	return o.Threshold
+}
+
+// orderBy returns the ORDER BY clause of a fuzzy search over a dated roster, ordering by
+// match score first unless the most recent roster entries were requested first.
+func (o FuzzyOptions) orderBy(date string) string {
+	if o.Sort == FuzzySortRecency {
+		return fmt.Sprintf("%s DESC, match_score DESC", date)
+	}
+	return fmt.Sprintf("match_score DESC, %s DESC", date)
+}
+
+// NameMatch describes how an officer matched a name search. It is embedded in the officer
+// models so its fields are reported alongside the officer's own.
+type NameMatch struct {
+	MatchScore      float64 `json:"match_score,omitempty"`
+	MatchedOn       string  `json:"matched_on,omitempty"`
+	MatchedNickname string  `json:"matched_nickname,omitempty"`
+}
+
+// fuzzyName describes a name searched by the fuzzy searches: the SQL expression holding
+// the normalized name (see normalize_name), the expression holding its precomputed
+// phonetic codes, the match source it is reported as and, for names including a first
+// name, how a nickname is substituted into the value searched.
+type fuzzyName struct {
+	expression string
+	phonetic   string
+	matchedOn  string
+	nickname   func(value, nickname string) string
+}
+
+var (
+	fuzzyFirstName = fuzzyName{"o.first_name_normalized", "o.first_name_phonetic", MatchedOnFirstName, nicknameAsFirstName}
+	fuzzyLastName  = fuzzyName{"o.last_name_normalized", "o.last_name_phonetic", MatchedOnLastName, nil}
+	fuzzyFullName  = fuzzyName{"o.first_name_normalized || ' ' || o.last_name_normalized", "o.first_name_phonetic || o.last_name_phonetic", MatchedOnFullName, nicknameInFullName}
+)
+
+// nicknameAsFirstName searches the nickname in place of the first name
+func nicknameAsFirstName(value, nickname string) string {
+	return nickname
+}
+
+// nicknameInFullName searches the full name with its first word replaced by the nickname
+func nicknameInFullName(value, nickname string) string {
+	words := strings.SplitN(strings.TrimSpace(value), " ", 2)
+	if len(words) < 2 {
+		return nickname
+	}
+	return nickname + " " + words[1]
+}
+
+// fuzzyMatch holds the SQL condition selecting the officers matching a fuzzy search, the
+// expressions scoring how well each of them matched and reporting what they matched on,
+// and the arguments all of them refer to.
+type fuzzyMatch struct {
+	condition string
+	score     string
+	matchedOn string
+	args      []interface{}
+}
+
+// match builds the SQL comparing the name against the searched value, normalized the same
+// way as the name. The value is bound to $1, its nickname variants to $2 and the similarity
+// threshold to $3.
+//
+// Trigram matching relies on pg_trgm, comparing similarities against the threshold
+// requested rather than the server wide pg_trgm.similarity_threshold. Setting the threshold
+// through set_limit would leak into the other searches sharing the pooled connection, so
+// the similarity is computed for every officer and the search is a full scan of the roster
+// rather than a trigram index lookup. Rosters are small enough for that to be cheap.
+//
+// Phonetic matching additionally accepts officers whose name shares Double Metaphone codes
+// with every word searched, ranking them above officers matched on spelling alone.
+func (n fuzzyName) match(value string, opts FuzzyOptions) fuzzyMatch {
+	variants := []string{}
+	if n.nickname != nil {
+		for _, nickname := range opts.Nicknames {
+			variants = append(variants, strings.ToLower(n.nickname(value, nickname)))
+		}
+	}
+	args := []interface{}{value, variants, opts.threshold()}
+
+	similarity := fmt.Sprintf("SIMILARITY(%s, normalize_name($1))", n.expression)
+	nicknameSimilarity := fmt.Sprintf("(SELECT MAX(SIMILARITY(%s, normalize_name(v))) FROM UNNEST($2::TEXT[]) v)", n.expression)
+	score := fmt.Sprintf("GREATEST(%s, %s)", similarity, nicknameSimilarity)
+	trigram := fmt.Sprintf("%s > $3", score)
+	condition := trigram
+	matchedOn := fmt.Sprintf("CASE WHEN %s > %s THEN '%s' ELSE '%s' END", nicknameSimilarity, similarity, MatchedOnNickname, n.matchedOn)
+
+	if opts.Mode == FuzzyModePhonetic {
+		phonetic := fmt.Sprintf("phonetic_match(%s, $1)", n.phonetic)
+		condition = fmt.Sprintf("(%s OR %s)", trigram, phonetic)
+		score = fmt.Sprintf("CASE WHEN %s THEN (1 + %s) / 2 ELSE %s END", phonetic, score, score)
+		matchedOn = fmt.Sprintf("CASE WHEN NOT %s THEN '%s' ELSE %s END", trigram, MatchedOnPhonetic, matchedOn)
+	}
+	score = fmt.Sprintf("ROUND((%s)::NUMERIC, 3)::FLOAT8", score)
+
+	if opts.MinScore > 0 {
+		args = append(args, opts.MinScore)
+		condition = fmt.Sprintf("%s AND %s >= $%d", condition, score, len(args))
+	}
+
+	return fuzzyMatch{condition, score, matchedOn, args}
+}
+
+// withMiddleName narrows the match down to the officers whose middle name column matches
+// the middle name searched, if any.
+func (m fuzzyMatch) withMiddleName(column, middleName string) fuzzyMatch {
+	if middleName == "" {
+		return m
+	}
+	m.args = append(m.args, middleName)
+	m.condition = fmt.Sprintf("%s AND %s", m.condition, middleNameMatch(fmt.Sprintf("COALESCE(%s, '')", column), len(m.args)))
+	return m
+}
+
+// fuzzyRows wraps the rows returned by a fuzzy search, whose officer columns are followed
+// by the match score and match source. Those are collected into matches as the rows are
+// marshalled, in the same order as the officers.
+type fuzzyRows struct {
+	pgx.Rows
+	matches []NameMatch
+}
+
+// Scan reads the officer columns into dest and the match columns into matches
+func (r *fuzzyRows) Scan(dest ...interface{}) error {
+	match := NameMatch{}
+	err := r.Rows.Scan(append(dest, &match.MatchScore, &match.MatchedOn)...)
+	if err != nil {
+		return err
+	}
+	r.matches = append(r.matches, match)
+	return nil
+}
@@ -13,10 +13,12 @@ import (
 type LakewoodOfficer struct {
 	Date            string `json:"date,omitempty"`
 	Title           string `json:"title,omitempty"`
+	CanonicalRank   string `json:"canonical_rank,omitempty"`
 	LastName        string `json:"last_name,omitempty"`
 	FirstName       string `json:"first_name,omitempty"`
 	Unit            string `json:"unit,omitempty"`
 	UnitDescription string `json:"unit_description,omitempty"`
+	NameMatch
 }
 
 // lakeOfficer is an internal intermediary between the returned SQL rows data
@@ -30,6 +32,13 @@ type lakewoodOfficer struct {
 	UnitDescription nulls.String
 }
 
+// lakewoodFilterColumns maps the filters supported by Lakewood strict searches onto their columns
+var lakewoodFilterColumns = map[string]string{
+	"title":            "o.title",
+	"unit":             "o.unit",
+	"unit_description": "o.unit_description",
+}
+
 // LakewoodOfficerMetadata retrieves metadata describing the LakewoodOfficer struct
 func (c *Client) LakewoodOfficerMetadata() *DepartmentMetadata {
 	var date time.Time
@@ -54,6 +63,10 @@ func (c *Client) LakewoodOfficerMetadata() *DepartmentMetadata {
 				"FieldName": "title",
 				"Label":     "Title",
 			},
+			{
+				"FieldName": "canonical_rank",
+				"Label":     "Canonical Rank",
+			},
 			{
 				"FieldName": "last_name",
 				"Label":     "Last Name",
@@ -77,20 +90,25 @@ func (c *Client) LakewoodOfficerMetadata() *DepartmentMetadata {
 		SearchRoutes: map[string]*SearchRouteMetadata{
 			"exact": {
 				Path:        "/lakewood/officer",
-				QueryParams: []string{"first_name", "last_name"},
+				QueryParams: []string{"q", "first_name", "last_name", "nicknames", "title", "unit", "unit_description", "mode", "rank"},
 			},
 			"fuzzy": {
 				Path:        "/lakewood/officer/search",
-				QueryParams: []string{"first_name", "last_name"},
+				QueryParams: []string{"q", "first_name", "last_name", "nicknames", "mode", "min_score", "threshold", "sort", "rank"},
+			},
+			"autocomplete": {
+				Path:        "/lakewood/officer/autocomplete",
+				QueryParams: []string{"prefix", "limit"},
 			},
 		},
 	}
 }
 
 // LakewoodSearchOfficerByName returns an officer by their first or last name.
-func (c *Client) LakewoodSearchOfficerByName(firstName, lastName string) ([]*LakewoodOfficer, error) {
+func (c *Client) LakewoodSearchOfficerByName(firstName, lastName string, nicknames []string, filters Filters) ([]*LakewoodOfficer, error) {
+	conditions, args := filters.conditions(lakewoodFilterColumns, []interface{}{firstName, lastName, nicknames})
 	rows, err := c.pool.Query(context.Background(),
-		`
+		fmt.Sprintf(`
 			SELECT
 				o.date,
 				o.title,
@@ -99,106 +117,88 @@ func (c *Client) LakewoodSearchOfficerByName(firstName, lastName string) ([]*Lak
 				o.unit,
 				o.unit_description
 			FROM lakewood_officers o
-			WHERE LOWER(o.first_name) LIKE LOWER($1) AND LOWER(o.last_name) LIKE LOWER($2)
+			WHERE (%s OR o.first_name_normalized = ANY($3)) AND %s%s
 			ORDER BY 
 				o.last_name,
 				o.first_name;
-		`,
-		firstName,
-		lastName,
+		`, filters.matchName("o.first_name", 1), filters.matchName("o.last_name", 2), conditions),
+		args...,
 	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	return lakewoodMarshalOfficerRows(rows)
+	return lakewoodMarshalOfficerRows(rows, nicknames...)
 }
 
 // LakewoodFuzzySearchByName returns an list of officers by their full name using fuzzy matching.
-// Entries are sorted by similarity to the full name in descending order.
-func (c *Client) LakewoodFuzzySearchByName(name string) ([]*LakewoodOfficer, error) {
-	rows, err := c.pool.Query(context.Background(),
-		`
-			SELECT
-				o.date,
-				o.title,
-				o.last_name,
-				o.first_name,
-				o.unit,
-				o.unit_description
-			FROM lakewood_officers o
-			WHERE LOWER(first_name || ' ' || last_name) % LOWER($1)
-			ORDER BY
-				SIMILARITY(LOWER(o.first_name || ' ' || o.last_name), LOWER($1)) DESC;
-		`,
-		name,
-	)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	return lakewoodMarshalOfficerRows(rows)
+// Entries are sorted by how well they match the full name in descending order,
+// or by roster date first when sorting by recency.
+func (c *Client) LakewoodFuzzySearchByName(name string, opts FuzzyOptions) ([]*LakewoodOfficer, error) {
+	return c.lakewoodFuzzySearch(fuzzyFullName, name, opts)
 }
 
 // LakewoodFuzzySearchByFirstName returns an list of officers by their first name using fuzzy matching.
-// Entries are sorted by similarity to the full name in descending order.
-func (c *Client) LakewoodFuzzySearchByFirstName(firstName string) ([]*LakewoodOfficer, error) {
+// Entries are sorted by how well they match the first name in descending order,
+// or by roster date first when sorting by recency.
+func (c *Client) LakewoodFuzzySearchByFirstName(firstName string, opts FuzzyOptions) ([]*LakewoodOfficer, error) {
+	return c.lakewoodFuzzySearch(fuzzyFirstName, firstName, opts)
+}
+
+// LakewoodFuzzySearchByLastName returns an list of officers by their last name using fuzzy matching.
+// Entries are sorted by how well they match the last name in descending order,
+// or by roster date first when sorting by recency.
+func (c *Client) LakewoodFuzzySearchByLastName(lastName string, opts FuzzyOptions) ([]*LakewoodOfficer, error) {
+	return c.lakewoodFuzzySearch(fuzzyLastName, lastName, opts)
+}
+
+// lakewoodFuzzySearch returns the officers whose name matches value, using the matching
+// requested through opts.
+func (c *Client) lakewoodFuzzySearch(name fuzzyName, value string, opts FuzzyOptions) ([]*LakewoodOfficer, error) {
+	match := name.match(value, opts)
 	rows, err := c.pool.Query(context.Background(),
-		`
+		fmt.Sprintf(`
 			SELECT
 				o.date,
 				o.title,
 				o.last_name,
 				o.first_name,
 				o.unit,
-				o.unit_description
+				o.unit_description,
+				%[2]s match_score,
+				%[3]s matched_on
 			FROM lakewood_officers o
-			WHERE LOWER(first_name) % LOWER($1)
-			ORDER BY
-				SIMILARITY(LOWER(o.first_name), LOWER($1)) DESC;
-		`,
-		firstName,
+			WHERE %[1]s
+			ORDER BY %[4]s;
+		`, match.condition, match.score, match.matchedOn, opts.orderBy("o.date")),
+		match.args...,
 	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	return lakewoodMarshalOfficerRows(rows)
-}
-
-// LakewoodFuzzySearchByLastName returns an list of officers by their last name using fuzzy matching.
-// Entries are sorted by similarity to the full name in descending order.
-func (c *Client) LakewoodFuzzySearchByLastName(lastName string) ([]*LakewoodOfficer, error) {
-	rows, err := c.pool.Query(context.Background(),
-		`
-			SELECT
-				o.date,
-				o.title,
-				o.last_name,
-				o.first_name,
-				o.unit,
-				o.unit_description
-			FROM lakewood_officers o
-			WHERE LOWER(last_name) % LOWER($1)
-			ORDER BY
-				SIMILARITY(LOWER(o.last_name), LOWER($1)) DESC;
-		`,
-		lastName,
-	)
+	fuzzy := &fuzzyRows{Rows: rows}
+	officers, err := lakewoodMarshalOfficerRows(fuzzy, opts.Nicknames...)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
+	for i, ofc := range officers {
+		ofc.MatchScore, ofc.MatchedOn = fuzzy.matches[i].MatchScore, fuzzy.matches[i].MatchedOn
+	}
+	return officers, nil
+}
 
-	return lakewoodMarshalOfficerRows(rows)
+// LakewoodHeadcountByDate returns the number of officers on each available roster date,
+// optionally grouped by title or unit.
+func (c *Client) LakewoodHeadcountByDate(groupBy string) ([]*HeadcountStat, error) {
+	return c.headcountByDate("lakewood_officers", groupBy)
 }
 
 // lakewoodMarshalOfficerRows takes SQL return objects and marshals them onto the
 // AuburnOfficer object for return as JSON by the API.
-func lakewoodMarshalOfficerRows(rows pgx.Rows) ([]*LakewoodOfficer, error) {
+func lakewoodMarshalOfficerRows(rows pgx.Rows, nicknames ...string) ([]*LakewoodOfficer, error) {
 	officers := []*LakewoodOfficer{}
 	for rows.Next() {
 		ofc := lakewoodOfficer{}
@@ -218,13 +218,21 @@ func lakewoodMarshalOfficerRows(rows pgx.Rows) ([]*LakewoodOfficer, error) {
 		returnOfficer := LakewoodOfficer{
 			ofc.Date.Format("2006-01-02"),
 			ofc.Title.String,
+			CanonicalRank(ofc.Title.String),
 			ofc.LastName.String,
 			ofc.FirstName.String,
 			ofc.Unit.String,
 			ofc.UnitDescription.String,
+			NameMatch{MatchedNickname: matchedNickname(ofc.FirstName.String, nicknames)},
 		}
 
 		officers = append(officers, &returnOfficer)
 	}
 	return officers, nil
 }
+
+// LakewoodAutocomplete suggests up to limit officer names starting with prefix, along with each
+// officer's badge and title.
+func (c *Client) LakewoodAutocomplete(prefix string, limit int) ([]*OfficerSuggestion, error) {
+	return c.autocomplete(namedAutocompleteSource("lakewood_officers", "", "o.title", "o.date"), prefix, limit)
+}
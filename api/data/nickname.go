@@ -0,0 +1,123 @@
+package data
+
+import (
+	"sort"
+	"strings"
+)
+
+// nicknameGroups lists given names along with the nicknames and variants commonly used in
+// their place. A name may appear in several groups, e.g. "al" for Albert, Alan and Alfred.
+// Nicknames mostly standing for other given names are left out of a group, so that searching
+// for Bob doesn't return every Bert (Albert, Herbert) and Patrick every Rick (Richard).
+var nicknameGroups = [][]string{
+	{"abraham", "abe", "bram"},
+	{"alan", "allan", "allen", "al"},
+	{"albert", "al", "bert", "bertie"},
+	{"alexander", "alex", "alec", "al", "sandy", "xander"},
+	{"alexandra", "alex", "alexa", "lexi", "sandra", "sandy"},
+	{"alfred", "al", "alf", "fred", "freddie"},
+	{"andrew", "andy", "drew"},
+	{"anthony", "tony", "ant"},
+	{"barbara", "barb", "barbie", "babs"},
+	{"benjamin", "ben", "benny", "benji"},
+	{"bradley", "brad"},
+	{"catherine", "katherine", "kathryn", "cathy", "kathy", "kate", "katie", "kat", "kit"},
+	{"charles", "charlie", "chuck", "chas", "chaz"},
+	{"christina", "christine", "chris", "christy", "tina"},
+	{"christopher", "chris", "kit", "topher"},
+	{"cynthia", "cindy"},
+	{"daniel", "dan", "danny"},
+	{"david", "dave", "davey"},
+	{"deborah", "debra", "deb", "debbie"},
+	{"dennis", "denny"},
+	{"donald", "don", "donnie"},
+	{"douglas", "doug"},
+	{"edward", "ed", "eddie", "ned", "ted", "teddy"},
+	{"elizabeth", "liz", "lizzie", "beth", "betsy", "betty", "eliza", "libby"},
+	{"eugene", "gene"},
+	{"frederick", "fred", "freddie"},
+	{"gerald", "gerry", "jerry"},
+	{"gregory", "greg"},
+	{"harold", "hal", "harry"},
+	{"henry", "hank", "harry", "hal"},
+	{"jacob", "jake", "jay"},
+	{"james", "jim", "jimmy", "jamie"},
+	{"jeffrey", "geoffrey", "jeff", "geoff"},
+	{"jennifer", "jen", "jenny", "jenn"},
+	{"jessica", "jess", "jessie"},
+	{"john", "jack", "johnny", "jon"},
+	{"jonathan", "jon", "jonny", "nate"},
+	{"joseph", "joe", "joey"},
+	{"joshua", "josh"},
+	{"kenneth", "ken", "kenny"},
+	{"lawrence", "laurence", "larry", "laurie"},
+	{"leonard", "leo", "len", "lenny"},
+	{"margaret", "maggie", "meg", "peggy", "marge", "greta"},
+	{"matthew", "matt", "matty"},
+	{"michael", "mike", "mikey", "mick", "mickey"},
+	{"nathan", "nathaniel", "nate", "nat"},
+	{"nicholas", "nick", "nicky", "nico"},
+	{"patricia", "pat", "patty", "trish", "tricia"},
+	{"patrick", "pat", "paddy"},
+	{"peter", "pete"},
+	{"philip", "phillip", "phil"},
+	{"raymond", "ray"},
+	{"rebecca", "becky", "becca"},
+	{"richard", "rick", "ricky", "rich", "richie", "dick"},
+	{"robert", "rob", "robbie", "bob", "bobby"},
+	{"ronald", "ron", "ronnie"},
+	{"samantha", "sam", "sammy"},
+	{"samuel", "sam", "sammy"},
+	{"stephen", "steven", "steve", "stevie"},
+	{"susan", "sue", "susie", "suzy"},
+	{"theodore", "ted", "teddy", "theo"},
+	{"thomas", "tom", "tommy"},
+	{"timothy", "tim", "timmy"},
+	{"victoria", "vicki", "vicky", "tori"},
+	{"walter", "walt", "wally"},
+	{"william", "will", "bill", "billy", "willie", "liam"},
+	{"zachary", "zach", "zack"},
+}
+
+// nicknameIndex maps every name in nicknameGroups onto the groups it appears in
+var nicknameIndex = buildNicknameIndex()
+
+func buildNicknameIndex() map[string][][]string {
+	index := map[string][][]string{}
+	for _, group := range nicknameGroups {
+		for _, name := range group {
+			index[name] = append(index[name], group)
+		}
+	}
+	return index
+}
+
+// Nicknames returns the lowercase nicknames and given name variants of name, e.g. "robert",
+// "rob" and "bobby" for "Bob". The name itself is not included.
+func Nicknames(name string) []string {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	seen := map[string]bool{name: true}
+	nicknames := []string{}
+	for _, group := range nicknameIndex[name] {
+		for _, nickname := range group {
+			if !seen[nickname] {
+				seen[nickname] = true
+				nicknames = append(nicknames, nickname)
+			}
+		}
+	}
+	sort.Strings(nicknames)
+	return nicknames
+}
+
+// matchedNickname returns the nickname an officer was matched through when their first name
+// is one of the nicknames searched for, or an empty string otherwise.
+func matchedNickname(firstName string, nicknames []string) string {
+	for _, nickname := range nicknames {
+		if strings.EqualFold(firstName, nickname) {
+			return nickname
+		}
+	}
+	return ""
+}
@@ -0,0 +1,43 @@
+package data
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNicknames(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		nicknames []string
+	}{
+		{"Bob", []string{"bobby", "rob", "robbie", "robert"}},
+		{" patrick ", []string{"paddy", "pat"}},
+		{"Rick", []string{"dick", "rich", "richard", "richie", "ricky"}},
+		{"Al", []string{"alan", "albert", "alec", "alex", "alexander", "alf", "alfred", "allan", "allen", "bert", "bertie", "fred", "freddie", "sandy", "xander"}},
+		{"Zelda", []string{}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if nicknames := Nicknames(tt.name); !reflect.DeepEqual(nicknames, tt.nicknames) {
+				t.Errorf("Nicknames(%q) = %v, want %v", tt.name, nicknames, tt.nicknames)
+			}
+		})
+	}
+}
+
+func TestMatchedNickname(t *testing.T) {
+	for _, tt := range []struct {
+		firstName string
+		nicknames []string
+		matched   string
+	}{
+		{"Robert", []string{"bobby", "rob", "robert"}, "robert"},
+		{"Bert", []string{"bobby", "rob", "robert"}, ""},
+		{"Bob", nil, ""},
+	} {
+		t.Run(tt.firstName, func(t *testing.T) {
+			if matched := matchedNickname(tt.firstName, tt.nicknames); matched != tt.matched {
+				t.Errorf("matchedNickname(%q, %v) = %q, want %q", tt.firstName, tt.nicknames, matched, tt.matched)
+			}
+		})
+	}
+}
@@ -11,12 +11,15 @@ import (
 
 // OlympiaOfficer is the object model for LPD officers
 type OlympiaOfficer struct {
-	Date      string `json:"date,omitempty"`
-	FirstName string `json:"first_name,omitempty"`
-	LastName  string `json:"last_name,omitempty"`
-	Title     string `json:"title,omitempty"`
-	Unit      string `json:"unit,omitempty"`
-	Badge     string `json:"badge,omitempty"`
+	Date            string `json:"date,omitempty"`
+	FirstName       string `json:"first_name,omitempty"`
+	LastName        string `json:"last_name,omitempty"`
+	Title           string `json:"title,omitempty"`
+	CanonicalRank   string `json:"canonical_rank,omitempty"`
+	Unit            string `json:"unit,omitempty"`
+	Badge           string `json:"badge,omitempty"`
+	BadgeNormalized string `json:"badge_normalized,omitempty"`
+	NameMatch
 }
 
 // olympiaOfficerOfficer is an internal intermediary between the returned SQL rows data
@@ -30,6 +33,12 @@ type olympiaOfficer struct {
 	Badge     nulls.String
 }
 
+// olympiaFilterColumns maps the filters supported by Olympia strict searches onto their columns
+var olympiaFilterColumns = map[string]string{
+	"title": "o.title",
+	"unit":  "o.unit",
+}
+
 // OlympiaOfficerMetadata retrieves metadata describing the OlympiaOfficer struct
 func (c *Client) OlympiaOfficerMetadata() *DepartmentMetadata {
 	var date time.Time
@@ -62,6 +71,10 @@ func (c *Client) OlympiaOfficerMetadata() *DepartmentMetadata {
 				"FieldName": "title",
 				"Label":     "Title",
 			},
+			{
+				"FieldName": "canonical_rank",
+				"Label":     "Canonical Rank",
+			},
 			{
 				"FieldName": "unit",
 				"Label":     "Unit",
@@ -77,20 +90,26 @@ func (c *Client) OlympiaOfficerMetadata() *DepartmentMetadata {
 		SearchRoutes: map[string]*SearchRouteMetadata{
 			"exact": {
 				Path:        "/olympia/officer",
-				QueryParams: []string{"badge", "first_name", "last_name"},
+				QueryParams: []string{"q", "badge", "badge_mode", "first_name", "last_name", "nicknames", "title", "unit", "mode", "rank"},
 			},
 			"fuzzy": {
 				Path:        "/olympia/officer/search",
-				QueryParams: []string{"first_name", "last_name"},
+				QueryParams: []string{"q", "first_name", "last_name", "nicknames", "mode", "min_score", "threshold", "sort", "rank"},
+			},
+			"autocomplete": {
+				Path:        "/olympia/officer/autocomplete",
+				QueryParams: []string{"prefix", "limit"},
 			},
 		},
 	}
 }
 
-// OlympiaGetOfficerByBadge returns an officer by their badge.
-func (c *Client) OlympiaGetOfficerByBadge(badge string) ([]*OlympiaOfficer, error) {
+// OlympiaGetOfficerByBadge returns the roster entries matching a badge search, ranked by how
+// close their badge is to the badge searched.
+func (c *Client) OlympiaGetOfficerByBadge(badge BadgeSearch) ([]*OlympiaOfficer, error) {
+	condition, distance, args := badge.match("o.badge_normalized")
 	rows, err := c.pool.Query(context.Background(),
-		`
+		fmt.Sprintf(`
 			SELECT
 				o.date,
 				o.first_name,
@@ -99,9 +118,13 @@ func (c *Client) OlympiaGetOfficerByBadge(badge string) ([]*OlympiaOfficer, erro
 				o.unit,
 				o.badge
 			FROM olympia_officers o
-			WHERE o.badge = $1;
-		`,
-		badge,
+			WHERE %s
+			ORDER BY
+				%s,
+				o.badge,
+				o.date DESC;
+		`, condition, distance),
+		args...,
 	)
 	if err != nil {
 		return nil, err
@@ -112,9 +135,10 @@ func (c *Client) OlympiaGetOfficerByBadge(badge string) ([]*OlympiaOfficer, erro
 }
 
 // OlympiaSearchOfficerByName returns an officer by their first or last name.
-func (c *Client) OlympiaSearchOfficerByName(firstName, lastName string) ([]*OlympiaOfficer, error) {
+func (c *Client) OlympiaSearchOfficerByName(firstName, lastName string, nicknames []string, filters Filters) ([]*OlympiaOfficer, error) {
+	conditions, args := filters.conditions(olympiaFilterColumns, []interface{}{firstName, lastName, nicknames})
 	rows, err := c.pool.Query(context.Background(),
-		`
+		fmt.Sprintf(`
 			SELECT
 				o.date,
 				o.first_name,
@@ -123,106 +147,88 @@ func (c *Client) OlympiaSearchOfficerByName(firstName, lastName string) ([]*Olym
 				o.unit,
 				o.badge
 			FROM olympia_officers o
-			WHERE LOWER(o.first_name) LIKE LOWER($1) AND LOWER(o.last_name) LIKE LOWER($2)
+			WHERE (%s OR o.first_name_normalized = ANY($3)) AND %s%s
 			ORDER BY 
 				o.last_name,
 				o.first_name;
-		`,
-		firstName,
-		lastName,
+		`, filters.matchName("o.first_name", 1), filters.matchName("o.last_name", 2), conditions),
+		args...,
 	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	return olympiaMarshalOfficerRows(rows)
+	return olympiaMarshalOfficerRows(rows, nicknames...)
 }
 
 // OlympiaFuzzySearchByName returns an list of officers by their full name using fuzzy matching.
-// Entries are sorted by similarity to the full name in descending order.
-func (c *Client) OlympiaFuzzySearchByName(name string) ([]*OlympiaOfficer, error) {
-	rows, err := c.pool.Query(context.Background(),
-		`
-			SELECT
-				o.date,
-				o.first_name,
-				o.last_name,
-				o.title,
-				o.unit,
-				o.badge
-			FROM olympia_officers o
-			WHERE LOWER(first_name || ' ' || last_name) % LOWER($1)
-			ORDER BY
-				SIMILARITY(LOWER(o.first_name || ' ' || o.last_name), LOWER($1)) DESC;
-		`,
-		name,
-	)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	return olympiaMarshalOfficerRows(rows)
+// Entries are sorted by how well they match the full name in descending order,
+// or by roster date first when sorting by recency.
+func (c *Client) OlympiaFuzzySearchByName(name string, opts FuzzyOptions) ([]*OlympiaOfficer, error) {
+	return c.olympiaFuzzySearch(fuzzyFullName, name, opts)
 }
 
 // OlympiaFuzzySearchByFirstName returns an list of officers by their first name using fuzzy matching.
-// Entries are sorted by similarity to the full name in descending order.
-func (c *Client) OlympiaFuzzySearchByFirstName(firstName string) ([]*OlympiaOfficer, error) {
+// Entries are sorted by how well they match the first name in descending order,
+// or by roster date first when sorting by recency.
+func (c *Client) OlympiaFuzzySearchByFirstName(firstName string, opts FuzzyOptions) ([]*OlympiaOfficer, error) {
+	return c.olympiaFuzzySearch(fuzzyFirstName, firstName, opts)
+}
+
+// OlympiaFuzzySearchByLastName returns an list of officers by their last name using fuzzy matching.
+// Entries are sorted by how well they match the last name in descending order,
+// or by roster date first when sorting by recency.
+func (c *Client) OlympiaFuzzySearchByLastName(lastName string, opts FuzzyOptions) ([]*OlympiaOfficer, error) {
+	return c.olympiaFuzzySearch(fuzzyLastName, lastName, opts)
+}
+
+// olympiaFuzzySearch returns the officers whose name matches value, using the matching
+// requested through opts.
+func (c *Client) olympiaFuzzySearch(name fuzzyName, value string, opts FuzzyOptions) ([]*OlympiaOfficer, error) {
+	match := name.match(value, opts)
 	rows, err := c.pool.Query(context.Background(),
-		`
+		fmt.Sprintf(`
 			SELECT
 				o.date,
 				o.first_name,
 				o.last_name,
 				o.title,
 				o.unit,
-				o.badge
+				o.badge,
+				%[2]s match_score,
+				%[3]s matched_on
 			FROM olympia_officers o
-			WHERE LOWER(first_name) % LOWER($1)
-			ORDER BY
-				SIMILARITY(LOWER(o.first_name), LOWER($1)) DESC;
-		`,
-		firstName,
+			WHERE %[1]s
+			ORDER BY %[4]s;
+		`, match.condition, match.score, match.matchedOn, opts.orderBy("o.date")),
+		match.args...,
 	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	return olympiaMarshalOfficerRows(rows)
-}
-
-// OlympiaFuzzySearchByLastName returns an list of officers by their last name using fuzzy matching.
-// Entries are sorted by similarity to the full name in descending order.
-func (c *Client) OlympiaFuzzySearchByLastName(lastName string) ([]*OlympiaOfficer, error) {
-	rows, err := c.pool.Query(context.Background(),
-		`
-			SELECT
-				o.date,
-				o.first_name,
-				o.last_name,
-				o.title,
-				o.unit,
-				o.badge
-			FROM olympia_officers o
-			WHERE LOWER(last_name) % LOWER($1)
-			ORDER BY
-				SIMILARITY(LOWER(o.last_name), LOWER($1)) DESC;
-		`,
-		lastName,
-	)
+	fuzzy := &fuzzyRows{Rows: rows}
+	officers, err := olympiaMarshalOfficerRows(fuzzy, opts.Nicknames...)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
+	for i, ofc := range officers {
+		ofc.MatchScore, ofc.MatchedOn = fuzzy.matches[i].MatchScore, fuzzy.matches[i].MatchedOn
+	}
+	return officers, nil
+}
 
-	return olympiaMarshalOfficerRows(rows)
+// OlympiaHeadcountByDate returns the number of officers on each available roster date,
+// optionally grouped by title or unit.
+func (c *Client) OlympiaHeadcountByDate(groupBy string) ([]*HeadcountStat, error) {
+	return c.headcountByDate("olympia_officers", groupBy)
 }
 
 // olympiaMarshalOfficerRows takes SQL return objects and marshals them onto the
 // OlympiaOfficer object for return as JSON by the API.
-func olympiaMarshalOfficerRows(rows pgx.Rows) ([]*OlympiaOfficer, error) {
+func olympiaMarshalOfficerRows(rows pgx.Rows, nicknames ...string) ([]*OlympiaOfficer, error) {
 	officers := []*OlympiaOfficer{}
 	for rows.Next() {
 		ofc := olympiaOfficer{}
@@ -244,11 +250,20 @@ func olympiaMarshalOfficerRows(rows pgx.Rows) ([]*OlympiaOfficer, error) {
 			ofc.FirstName.String,
 			ofc.LastName.String,
 			ofc.Title.String,
+			CanonicalRank(ofc.Title.String),
 			ofc.Unit.String,
 			ofc.Badge.String,
+			NormalizeBadge(ofc.Badge.String),
+			NameMatch{MatchedNickname: matchedNickname(ofc.FirstName.String, nicknames)},
 		}
 
 		officers = append(officers, &returnOfficer)
 	}
 	return officers, nil
 }
+
+// OlympiaAutocomplete suggests up to limit officer names starting with prefix, along with each
+// officer's badge and title.
+func (c *Client) OlympiaAutocomplete(prefix string, limit int) ([]*OfficerSuggestion, error) {
+	return c.autocomplete(namedAutocompleteSource("olympia_officers", "o.badge", "o.title", "o.date"), prefix, limit)
+}
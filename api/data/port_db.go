@@ -2,6 +2,8 @@ package data
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/gobuffalo/nulls"
 	"github.com/jackc/pgx/v4"
@@ -9,21 +11,42 @@ import (
 
 // PortOfSeattleOfficer is the object model for BPD officers
 type PortOfSeattleOfficer struct {
-	Name  string `json:"name,omitempty"`
-	Rank  string `json:"rank,omitempty"`
-	Unit  string `json:"unit,omitempty"`
-	Badge string `json:"badge,omitempty"`
+	Name            string `json:"name,omitempty"`
+	FirstName       string `json:"first_name,omitempty"`
+	LastName        string `json:"last_name,omitempty"`
+	Rank            string `json:"rank,omitempty"`
+	CanonicalRank   string `json:"canonical_rank,omitempty"`
+	Unit            string `json:"unit,omitempty"`
+	Badge           string `json:"badge,omitempty"`
+	BadgeNormalized string `json:"badge_normalized,omitempty"`
+	HireYear        int    `json:"hire_year,omitempty"`
+	TenureYears     *int   `json:"tenure_years,omitempty"`
+	NameMatch
 }
 
 // portOfSeattleOfficer is an internal intermediary between the returned SQL rows data
 // and the actual JSON return itself.
 type portOfSeattleOfficer struct {
-	Name  nulls.String
-	Rank  nulls.String
-	Unit  nulls.String
-	Badge nulls.String
+	Name      nulls.String
+	FirstName nulls.String
+	LastName  nulls.String
+	Rank      nulls.String
+	Unit      nulls.String
+	Badge     nulls.String
+	HireYear  nulls.Int
 }
 
+// portOfSeattleFilterColumns maps the filters supported by PortOfSeattle strict searches onto their columns
+var portOfSeattleFilterColumns = map[string]string{
+	"title":     "o.rank",
+	"unit":      "o.unit",
+	"hire_year": "o.hire_date",
+}
+
+// portOfSeattleRosterDate is the date of the Port of Seattle roster, which officers' years of
+// service are counted up to.
+var portOfSeattleRosterDate = time.Date(2021, time.May, 1, 0, 0, 0, 0, time.UTC)
+
 // PortOfSeattleOfficerMetadata retrieves metadata describing the PortOfSeattleOfficer struct
 func (c *Client) PortOfSeattleOfficerMetadata() *DepartmentMetadata {
 	return &DepartmentMetadata{
@@ -32,10 +55,22 @@ func (c *Client) PortOfSeattleOfficerMetadata() *DepartmentMetadata {
 				"FieldName": "name",
 				"Label":     "Full Name",
 			},
+			{
+				"FieldName": "first_name",
+				"Label":     "First Name",
+			},
+			{
+				"FieldName": "last_name",
+				"Label":     "Last Name",
+			},
 			{
 				"FieldName": "rank",
 				"Label":     "Officer Title",
 			},
+			{
+				"FieldName": "canonical_rank",
+				"Label":     "Canonical Rank",
+			},
 			{
 				"FieldName": "unit",
 				"Label":     "Officer unit",
@@ -44,38 +79,56 @@ func (c *Client) PortOfSeattleOfficerMetadata() *DepartmentMetadata {
 				"FieldName": "badge",
 				"Label":     "Badge number",
 			},
+			{
+				"FieldName": "hire_year",
+				"Label":     "Hire Year",
+			},
+			{
+				"FieldName": "tenure_years",
+				"Label":     "Years of Service",
+			},
 		},
-		LastAvailableRosterDate: "2021-05-01",
+		LastAvailableRosterDate: portOfSeattleRosterDate.Format("2006-01-02"),
 		Name:                    "Port Of Seattle PD",
 		ID:                      "pospd",
 		SearchRoutes: map[string]*SearchRouteMetadata{
 			"exact": {
 				Path:        "/port_of_seattle/officer",
-				QueryParams: []string{"badge", "name"},
+				QueryParams: []string{"q", "badge", "badge_mode", "first_name", "last_name", "nicknames", "title", "unit", "hire_year_min", "hire_year_max", "mode", "rank"},
 			},
 			"fuzzy": {
 				Path:        "/port_of_seattle/officer/search",
-				QueryParams: []string{"name"},
+				QueryParams: []string{"q", "first_name", "last_name", "nicknames", "mode", "min_score", "threshold", "rank"},
+			},
+			"autocomplete": {
+				Path:        "/port_of_seattle/officer/autocomplete",
+				QueryParams: []string{"prefix", "limit"},
 			},
 		},
 	}
 }
 
-// PortOfSeattleSearchOfficerByName returns an officer by their name.
-func (c *Client) PortOfSeattleSearchOfficerByBadge(badge string) ([]*PortOfSeattleOfficer, error) {
+// PortOfSeattleSearchOfficerByBadge returns the officers matching a badge search, ranked by
+// how close their badge is to the badge searched.
+func (c *Client) PortOfSeattleSearchOfficerByBadge(badge BadgeSearch) ([]*PortOfSeattleOfficer, error) {
+	condition, distance, args := badge.match("o.badge_normalized")
 	rows, err := c.pool.Query(context.Background(),
-		`
+		fmt.Sprintf(`
 			SELECT
 				o.name,
+				o.first_name,
+				o.last_name,
 				o.rank,
 				o.unit,
-                o.badge_number
+                o.badge_number,
+                o.hire_date
 			FROM port_of_seattle_officers o
-			WHERE o.badge_number=$1
+			WHERE %s
 			ORDER BY 
+				%s,
 				o.badge_number;
-		`,
-		badge,
+		`, condition, distance),
+		args...,
 	)
 	if err != nil {
 		return nil, err
@@ -85,47 +138,56 @@ func (c *Client) PortOfSeattleSearchOfficerByBadge(badge string) ([]*PortOfSeatt
 	return portOfSeattleMarshalOfficerRows(rows)
 }
 
-// PortOfSeattleSearchOfficerByName returns an officer by their name.
-func (c *Client) PortOfSeattleSearchOfficerByName(name string) ([]*PortOfSeattleOfficer, error) {
+// PortOfSeattleSearchOfficerByName returns an officer by their first or last name.
+func (c *Client) PortOfSeattleSearchOfficerByName(firstName, lastName string, nicknames []string, filters Filters) ([]*PortOfSeattleOfficer, error) {
+	conditions, args := filters.conditions(portOfSeattleFilterColumns, []interface{}{firstName, lastName, nicknames})
 	rows, err := c.pool.Query(context.Background(),
-		`
+		fmt.Sprintf(`
 			SELECT
 				o.name,
+				o.first_name,
+				o.last_name,
 				o.rank,
 				o.unit,
-                o.badge_number
+				o.badge_number,
+				o.hire_date
 			FROM port_of_seattle_officers o
-			WHERE LOWER(o.name) LIKE LOWER($1)
-			ORDER BY 
-				o.name;
-		`,
-		name,
+			WHERE (%s OR o.first_name_normalized = ANY($3)) AND %s%s
+			ORDER BY
+				o.last_name,
+				o.first_name;
+		`, filters.matchName("o.first_name", 1), filters.matchName("o.last_name", 2), conditions),
+		args...,
 	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	return portOfSeattleMarshalOfficerRows(rows)
+	return portOfSeattleMarshalOfficerRows(rows, nicknames...)
 }
 
-// PortOfSeattleFuzzySearchByName returns an list of officers by their full name using fuzzy matching.
-// Entries are sorted by similarity to the full name in descending order.
-func (c *Client) PortOfSeattleFuzzySearchByName(name string) ([]*PortOfSeattleOfficer, error) {
+// PortOfSeattleSearchOfficersByFilters returns the officers matching the range filters of
+// filters, such as a hire year range.
+func (c *Client) PortOfSeattleSearchOfficersByFilters(filters Filters) ([]*PortOfSeattleOfficer, error) {
+	conditions, args := filters.conditions(portOfSeattleFilterColumns, []interface{}{})
 	rows, err := c.pool.Query(context.Background(),
-		`
+		fmt.Sprintf(`
 			SELECT
 				o.name,
+				o.first_name,
+				o.last_name,
 				o.rank,
 				o.unit,
-                o.badge_number
-
+				o.badge_number,
+				o.hire_date
 			FROM port_of_seattle_officers o
-			WHERE LOWER(name || ' ' ) % LOWER($1)
+			WHERE TRUE%s
 			ORDER BY
-				SIMILARITY(LOWER(' ' || o.name), LOWER($1)) DESC;
-		`,
-		name,
+				o.last_name,
+				o.first_name;
+		`, conditions),
+		args...,
 	)
 	if err != nil {
 		return nil, err
@@ -135,17 +197,76 @@ func (c *Client) PortOfSeattleFuzzySearchByName(name string) ([]*PortOfSeattleOf
 	return portOfSeattleMarshalOfficerRows(rows)
 }
 
+// PortOfSeattleFuzzySearchByName returns an list of officers by their full name using fuzzy matching.
+// Entries are sorted by how well they match the full name in descending order.
+func (c *Client) PortOfSeattleFuzzySearchByName(name string, opts FuzzyOptions) ([]*PortOfSeattleOfficer, error) {
+	return c.portOfSeattleFuzzySearch(fuzzyFullName, name, opts)
+}
+
+// PortOfSeattleFuzzySearchByFirstName returns an list of officers by their first name using fuzzy matching.
+// Entries are sorted by how well they match the first name in descending order.
+func (c *Client) PortOfSeattleFuzzySearchByFirstName(firstName string, opts FuzzyOptions) ([]*PortOfSeattleOfficer, error) {
+	return c.portOfSeattleFuzzySearch(fuzzyFirstName, firstName, opts)
+}
+
+// PortOfSeattleFuzzySearchByLastName returns an list of officers by their last name using fuzzy matching.
+// Entries are sorted by how well they match the last name in descending order.
+func (c *Client) PortOfSeattleFuzzySearchByLastName(lastName string, opts FuzzyOptions) ([]*PortOfSeattleOfficer, error) {
+	return c.portOfSeattleFuzzySearch(fuzzyLastName, lastName, opts)
+}
+
+// portOfSeattleFuzzySearch returns the officers whose name matches value, using the matching
+// requested through opts.
+func (c *Client) portOfSeattleFuzzySearch(name fuzzyName, value string, opts FuzzyOptions) ([]*PortOfSeattleOfficer, error) {
+	match := name.match(value, opts)
+	rows, err := c.pool.Query(context.Background(),
+		fmt.Sprintf(`
+			SELECT
+				o.name,
+				o.first_name,
+				o.last_name,
+				o.rank,
+				o.unit,
+				o.badge_number,
+				o.hire_date,
+				%[2]s match_score,
+				%[3]s matched_on
+			FROM port_of_seattle_officers o
+			WHERE %[1]s
+			ORDER BY match_score DESC;
+		`, match.condition, match.score, match.matchedOn),
+		match.args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	fuzzy := &fuzzyRows{Rows: rows}
+	officers, err := portOfSeattleMarshalOfficerRows(fuzzy, opts.Nicknames...)
+	if err != nil {
+		return nil, err
+	}
+	for i, ofc := range officers {
+		ofc.MatchScore, ofc.MatchedOn = fuzzy.matches[i].MatchScore, fuzzy.matches[i].MatchedOn
+	}
+	return officers, nil
+}
+
 // portOfSeattleMarshalOfficerRows takes SQL return objects and marshals them onto the
 // PortOfSeattleOfficer object for return as JSON by the API.
-func portOfSeattleMarshalOfficerRows(rows pgx.Rows) ([]*PortOfSeattleOfficer, error) {
+func portOfSeattleMarshalOfficerRows(rows pgx.Rows, nicknames ...string) ([]*PortOfSeattleOfficer, error) {
 	officers := []*PortOfSeattleOfficer{}
 	for rows.Next() {
 		ofc := portOfSeattleOfficer{}
 		err := rows.Scan(
 			&ofc.Name,
+			&ofc.FirstName,
+			&ofc.LastName,
 			&ofc.Rank,
 			&ofc.Unit,
 			&ofc.Badge,
+			&ofc.HireYear,
 		)
 
 		if err != nil {
@@ -154,12 +275,35 @@ func portOfSeattleMarshalOfficerRows(rows pgx.Rows) ([]*PortOfSeattleOfficer, er
 
 		returnOfficer := PortOfSeattleOfficer{
 			ofc.Name.String,
+			ofc.FirstName.String,
+			ofc.LastName.String,
 			ofc.Rank.String,
+			CanonicalRank(ofc.Rank.String),
 			ofc.Unit.String,
 			ofc.Badge.String,
+			NormalizeBadge(ofc.Badge.String),
+			ofc.HireYear.Int,
+			portOfSeattleTenure(ofc.HireYear),
+			NameMatch{MatchedNickname: matchedNickname(ofc.FirstName.String, nicknames)},
 		}
 
 		officers = append(officers, &returnOfficer)
 	}
 	return officers, nil
 }
+
+// portOfSeattleTenure counts the years of service of an officer hired in hireYear up to the
+// roster date. Only hire years are recorded, so it is the difference between the years.
+func portOfSeattleTenure(hireYear nulls.Int) *int {
+	if !hireYear.Valid {
+		return nil
+	}
+	tenure := portOfSeattleRosterDate.Year() - hireYear.Int
+	return &tenure
+}
+
+// PortOfSeattleAutocomplete suggests up to limit officer names starting with prefix, along with each
+// officer's badge and title.
+func (c *Client) PortOfSeattleAutocomplete(prefix string, limit int) ([]*OfficerSuggestion, error) {
+	return c.autocomplete(namedAutocompleteSource("port_of_seattle_officers", "o.badge_number", "o.rank", ""), prefix, limit)
+}
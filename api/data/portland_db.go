@@ -10,42 +10,92 @@ import (
 	"github.com/jackc/pgx/v4"
 )
 
-// PortlandOfficer is the object model for PPB officers
+// PortlandOfficer is the object model for PPB officers. Dates are formatted as YYYY-MM-DD,
+// and yes/no flags and salaries the roster leaves blank are omitted.
 type PortlandOfficer struct {
-	FirstName                nulls.String `json:"first_name,omitempty"`
-	LastName                 nulls.String `json:"last_name,omitempty"`
-	Gender                   nulls.String `json:"gender,omitempty"`
-	OfficerRank              nulls.String `json:"officer_rank,omitempty"`
-	EmployeeID               nulls.String `json:"employee_id,omitempty"`
-	HelmetID                 nulls.String `json:"helmet_id,omitempty"`
-	HelmetIDThreeDigit       nulls.String `json:"helmet_id_three_digit,omitempty"`
-	Salary                   nulls.String `json:"salary,omitempty"`
-	Badge                    nulls.String `json:"badge,omitempty"`
-	CopsPhotoProfileLink     nulls.String `json:"cops_photo_profile_link,omitempty"`
-	CopsPhotoHasPhoto        nulls.String `json:"cops_photo_has_photo,omitempty"`
-	Employed_3_12_21         nulls.String `json:"employed_3_12_21,omitempty"`
-	Employed_12_28_20        nulls.String `json:"employed_12_28_20,omitempty"`
-	Employed_10_01_20        nulls.String `json:"employed_10_01_20,omitempty"`
-	Retired_6_1_20           nulls.String `json:"retired_6_1_20,omitempty"`
-	RetiredOrCertRevoked     nulls.String `json:"retired_or_cert_revoked,omitempty"`
-	RetiredOrCertRevokedDate nulls.String `json:"retired_or_cert_revoked_date,omitempty"`
-	HireYear                 nulls.String `json:"hire_year,omitempty"`
-	HireDate                 nulls.String `json:"hire_date,omitempty"`
-	StateCertDate            nulls.String `json:"state_cert_date,omitempty"`
-	StateCertLevel           nulls.String `json:"state_cert_level,omitempty"`
-	RRT                      nulls.String `json:"rrt,omitempty"`
-	RRT2016                  nulls.String `json:"rrt_2016,omitempty"`
-	RRT2018NiiyaEmail        nulls.String `json:"rrt_2018_niiya_email,omitempty"`
-	RRT2018                  nulls.String `json:"rrt_2018,omitempty"`
-	RRT2019                  nulls.String `json:"rrt_2019,omitempty"`
-	RRT2020                  nulls.String `json:"rrt_2020,omitempty"`
-	SoundTruckTraining       nulls.String `json:"sound_truck_training_2020,omitempty"`
-	InstructedForDpsst       nulls.String `json:"instructed_for_dpsst,omitempty"`
-	InstructedForLessLethal  nulls.String `json:"instructed_for_less_lethal,omitempty"`
-	InvolvedInOisUof         nulls.String `json:"involved_in_ois_uof,omitempty"`
-	Notes                    nulls.String `json:"notes,omitempty"`
+	FirstName                string   `json:"first_name,omitempty"`
+	LastName                 string   `json:"last_name,omitempty"`
+	Gender                   string   `json:"gender,omitempty"`
+	OfficerRank              string   `json:"officer_rank,omitempty"`
+	CanonicalRank            string   `json:"canonical_rank,omitempty"`
+	EmployeeID               string   `json:"employee_id,omitempty"`
+	HelmetID                 string   `json:"helmet_id,omitempty"`
+	HelmetIDThreeDigit       string   `json:"helmet_id_three_digit,omitempty"`
+	Salary                   string   `json:"salary,omitempty"`
+	SalaryAmount             *float64 `json:"salary_amount,omitempty"`
+	Badge                    string   `json:"badge,omitempty"`
+	BadgeNormalized          string   `json:"badge_normalized,omitempty"`
+	CopsPhotoProfileLink     string   `json:"cops_photo_profile_link,omitempty"`
+	CopsPhotoHasPhoto        *bool    `json:"cops_photo_has_photo,omitempty"`
+	Date                     string   `json:"date,omitempty"`
+	Current                  bool     `json:"is_current"`
+	TenureYears              *int     `json:"tenure_years,omitempty"`
+	RetiredOrCertRevoked     *bool    `json:"retired_or_cert_revoked,omitempty"`
+	RetiredOrCertRevokedDate string   `json:"retired_or_cert_revoked_date,omitempty"`
+	HireYear                 int      `json:"hire_year,omitempty"`
+	HireDate                 string   `json:"hire_date,omitempty"`
+	StateCertDate            string   `json:"state_cert_date,omitempty"`
+	StateCertLevel           string   `json:"state_cert_level,omitempty"`
+	RRT                      *bool    `json:"rrt,omitempty"`
+	RRT2016                  *bool    `json:"rrt_2016,omitempty"`
+	RRT2018NiiyaEmail        *bool    `json:"rrt_2018_niiya_email,omitempty"`
+	RRT2018                  *bool    `json:"rrt_2018,omitempty"`
+	RRT2019                  *bool    `json:"rrt_2019,omitempty"`
+	RRT2020                  *bool    `json:"rrt_2020,omitempty"`
+	SoundTruckTraining       *bool    `json:"sound_truck_training_2020,omitempty"`
+	InstructedForDpsst       *bool    `json:"instructed_for_dpsst,omitempty"`
+	InstructedForLessLethal  *bool    `json:"instructed_for_less_lethal,omitempty"`
+	InvolvedInOisUof         *bool    `json:"involved_in_ois_uof,omitempty"`
+	Notes                    string   `json:"notes,omitempty"`
+	MatchedIDs               []string `json:"matched_ids,omitempty"`
+	NameMatch
 }
 
+// portlandOfficer is an internal intermediary between the returned SQL rows data
+// and the actual JSON return itself.
+type portlandOfficer struct {
+	FirstName                nulls.String
+	LastName                 nulls.String
+	Gender                   nulls.String
+	OfficerRank              nulls.String
+	EmployeeID               nulls.String
+	HelmetID                 nulls.String
+	HelmetIDThreeDigit       nulls.String
+	Salary                   nulls.String
+	SalaryAmount             nulls.Float64
+	Badge                    nulls.String
+	CopsPhotoProfileLink     nulls.String
+	CopsPhotoHasPhoto        nulls.Bool
+	Date                     nulls.Time
+	Current                  bool
+	TenureYears              nulls.Int
+	RetiredOrCertRevoked     nulls.Bool
+	RetiredOrCertRevokedDate nulls.Time
+	HireYear                 nulls.Int
+	HireDate                 nulls.Time
+	StateCertDate            nulls.Time
+	StateCertLevel           nulls.String
+	RRT                      nulls.Bool
+	RRT2016                  nulls.Bool
+	RRT2018NiiyaEmail        nulls.Bool
+	RRT2018                  nulls.Bool
+	RRT2019                  nulls.Bool
+	RRT2020                  nulls.Bool
+	SoundTruckTraining       nulls.Bool
+	InstructedForDpsst       nulls.Bool
+	InstructedForLessLethal  nulls.Bool
+	InvolvedInOisUof         nulls.Bool
+	Notes                    nulls.String
+}
+
+// Identifiers searched by Portland identifier lookups, reported through PortlandOfficer.MatchedIDs
+const (
+	PortlandIDBadge              = "badge"
+	PortlandIDEmployeeID         = "employee_id"
+	PortlandIDHelmetID           = "helmet_id"
+	PortlandIDHelmetIDThreeDigit = "helmet_id_three_digit"
+)
+
 func maxDate(dates ...time.Time) time.Time {
 	t := time.Time{}
 	for _, t2 := range dates {
@@ -56,84 +106,65 @@ func maxDate(dates ...time.Time) time.Time {
 	return t
 }
 
-func portlandParseDate(s string) (time.Time, error) {
-	date, err := time.Parse("2006-01-02", s)
-	if err == nil {
-		return date, nil
-	}
-	date, err = time.Parse("1/2/06", s)
-	if err == nil {
-		return date, nil
-	}
-	date, err = time.Parse("1/2/2006", s)
-	if err == nil {
-		return date, nil
-	}
-	if strings.ToUpper(s) == "N/A" {
-		return time.Time{}, nil
-	}
-	return time.Time{}, err
+// portlandFilterColumns maps the filters supported by Portland strict searches onto their columns
+var portlandFilterColumns = map[string]string{
+	"title":                      "o.officer_rank",
+	"rrt":                        "o.rrt",
+	"sound_truck_training_2020":  "o.sound_truck_training_2020",
+	"instructed_for_dpsst":       "o.instructed_for_dpsst",
+	"instructed_for_less_lethal": "o.instructed_for_less_lethal",
+	"involved_in_ois_uof":        "o.involved_in_ois_uof",
+	"hire_year":                  "COALESCE(o.hire_year, DATE_PART('year', o.hire_date)::INTEGER)",
+	"salary":                     "o.salary_amount",
 }
 
-// PortlandOfficerMetadata retrieves metadata describing the PortlandOfficer struct
-func (c *Client) PortlandOfficerMetadata() *DepartmentMetadata {
-	var cert_revoked_date_string string
-	err := c.pool.QueryRow(context.Background(),
-		`
-			SELECT max(retired_or_cert_revoked_date) as cert_revoked_date
-			FROM portland_officers;
-		`).Scan(&cert_revoked_date_string)
-	if err != nil {
-		fmt.Printf("DB Client Error: %s\n", err)
-		return &DepartmentMetadata{}
-	}
-	cert_revoked_date, err := portlandParseDate(cert_revoked_date_string)
-	if err != nil {
-		fmt.Printf("Date Error: %s\n", err)
-		return &DepartmentMetadata{}
-	}
+// portlandSalaryGroupColumns maps the group_by values supported by Portland salary statistics
+// onto the columns they group by
+var portlandSalaryGroupColumns = map[string]string{
+	"title": "o.officer_rank",
+}
 
-	var hire_date_string string
-	err = c.pool.QueryRow(context.Background(),
-		`
-			SELECT max(hire_date) as hire_date
-			FROM portland_officers;
-		`).Scan(&hire_date_string)
-	if err != nil {
-		fmt.Printf("DB Client Error: %s\n", err)
-		return &DepartmentMetadata{}
-	}
+// PortlandFlagFilters lists the yes/no filters supported by Portland strict searches
+var PortlandFlagFilters = []string{"rrt", "sound_truck_training_2020", "instructed_for_dpsst", "instructed_for_less_lethal", "involved_in_ois_uof"}
 
-	hire_date, err := portlandParseDate(hire_date_string)
-	if err != nil {
-		fmt.Printf("Date Error: %s\n", err)
-		return &DepartmentMetadata{}
-	}
+// PortlandRRTYears lists the years Portland's Rapid Response Team membership is recorded for
+var PortlandRRTYears = []int{2016, 2018}
+
+// PortlandRRTTrainingYears lists the years Portland's RRT specific training is recorded for
+var PortlandRRTTrainingYears = []int{2018, 2019, 2020}
 
-	var cert_date_string string
-	err = c.pool.QueryRow(context.Background(),
+// portlandRRTColumns maps each year in PortlandRRTYears onto the column recording the RRT
+// members of that year
+var portlandRRTColumns = map[int]string{
+	2016: "o.rrt_2016",
+	2018: "o.rrt_2018_niiya_email",
+}
+
+// portlandRRTTrainingColumns maps each year in PortlandRRTTrainingYears onto the column
+// recording the officers who completed RRT specific training that year
+var portlandRRTTrainingColumns = map[int]string{
+	2018: "o.rrt_2018",
+	2019: "o.rrt_2019",
+	2020: "o.rrt_2020",
+}
+
+// PortlandOfficerMetadata retrieves metadata describing the PortlandOfficer struct
+func (c *Client) PortlandOfficerMetadata() *DepartmentMetadata {
+	var roster_date, cert_revoked_date, hire_date, cert_date nulls.Time
+	err := c.pool.QueryRow(context.Background(),
 		`
-			SELECT max(state_cert_date) as cert_date
+			SELECT
+				(SELECT max(date) FROM portland_roster_history) as roster_date,
+				max(retired_or_cert_revoked_date) as cert_revoked_date,
+				max(hire_date) as hire_date,
+				max(state_cert_date) as cert_date
 			FROM portland_officers;
-		`).Scan(&cert_date_string)
+		`).Scan(&roster_date, &cert_revoked_date, &hire_date, &cert_date)
 	if err != nil {
 		fmt.Printf("DB Client Error: %s\n", err)
 		return &DepartmentMetadata{}
 	}
-	cert_date, err := portlandParseDate(cert_date_string)
-	if err != nil {
-		fmt.Printf("Date Error: %s\n", err)
-		return &DepartmentMetadata{}
-	}
-
-	// This is the latest date manually added - if the data catches up / exceeds this date it will automatically use the most
-	// recent date.
-	manual_date, err := time.Parse("2006-01-02", "2021-03-12")
-	if err != nil {
-		fmt.Printf("Date Error: %s\n", err)
-		return &DepartmentMetadata{}
-	}
-	max_date := maxDate(hire_date, cert_revoked_date, cert_date, manual_date)
+	max_date := maxDate(roster_date.Time, hire_date.Time, cert_revoked_date.Time, cert_date.Time)
 
 	return &DepartmentMetadata{
 		Fields: []map[string]string{
@@ -153,6 +184,10 @@ func (c *Client) PortlandOfficerMetadata() *DepartmentMetadata {
 				"FieldName": "officer_rank",
 				"Label":     "Rank",
 			},
+			{
+				"FieldName": "canonical_rank",
+				"Label":     "Canonical Rank",
+			},
 			{
 				"FieldName": "employee_id",
 				"Label":     "Employee (Chest) ID",
@@ -169,6 +204,10 @@ func (c *Client) PortlandOfficerMetadata() *DepartmentMetadata {
 				"FieldName": "salary",
 				"Label":     "Fiscal Earnings 2019",
 			},
+			{
+				"FieldName": "salary_amount",
+				"Label":     "Fiscal Earnings 2019 (Amount)",
+			},
 			{
 				"FieldName": "badge",
 				"Label":     "Badge/DPSST Number",
@@ -182,20 +221,16 @@ func (c *Client) PortlandOfficerMetadata() *DepartmentMetadata {
 				"Label":     "Pic on Cops.photo (y/n)",
 			},
 			{
-				"FieldName": "employed_3_12_21",
-				"Label":     "Employed as of 3/12/21",
-			},
-			{
-				"FieldName": "employed_12_28_20",
-				"Label":     "Employed as of 12/28/20",
+				"FieldName": "date",
+				"Label":     "Last Roster Date",
 			},
 			{
-				"FieldName": "employed_10_01_20",
-				"Label":     "Employed as of 10/01/20",
+				"FieldName": "is_current",
+				"Label":     "On Current Roster",
 			},
 			{
-				"FieldName": "retired_6_1_20",
-				"Label":     "Retired/Resigned as of 6/1/20",
+				"FieldName": "tenure_years",
+				"Label":     "Years of Service",
 			},
 			{
 				"FieldName": "retired_or_cert_revoked",
@@ -272,17 +307,30 @@ func (c *Client) PortlandOfficerMetadata() *DepartmentMetadata {
 		SearchRoutes: map[string]*SearchRouteMetadata{
 			"exact": {
 				Path:        "/portland/officer",
-				QueryParams: []string{"badge", "first_name", "last_name", "employee_id", "helmet_id", "helmet_id_three_digit"},
+				QueryParams: []string{"q", "id", "badge", "first_name", "last_name", "nicknames", "employee_id", "helmet_id", "helmet_id_three_digit", "title", "rrt", "sound_truck_training_2020", "instructed_for_dpsst", "instructed_for_less_lethal", "involved_in_ois_uof", "hire_year_min", "hire_year_max", "salary_min", "salary_max", "mode", "rank"},
 			},
 			"fuzzy": {
 				Path:        "/portland/officer/search",
-				QueryParams: []string{"first_name", "last_name"},
+				QueryParams: []string{"q", "first_name", "last_name", "nicknames", "mode", "min_score", "threshold", "rank"},
+			},
+			"autocomplete": {
+				Path:        "/portland/officer/autocomplete",
+				QueryParams: []string{"prefix", "limit"},
+			},
+			"historical-exact": {
+				Path:        "/portland/officer/historical",
+				QueryParams: []string{"badge", "rank"},
+			},
+			"rrt": {
+				Path:        "/portland/officer/rrt",
+				QueryParams: []string{"year", "training", "rrt", "sound_truck_training_2020", "instructed_for_dpsst", "instructed_for_less_lethal", "involved_in_ois_uof", "rank"},
 			},
 		},
 	}
 }
 
-// PortlandSearchOfficersByBadge invokes portland_search_officer_by_badge_p
+// PortlandSearchOfficersByBadge returns the officers whose DPSST number matches badge once
+// both are normalized.
 func (c *Client) PortlandSearchOfficersByBadge(badge string) ([]*PortlandOfficer, error) {
 	rows, err := c.pool.Query(context.Background(),
 		`
@@ -295,13 +343,13 @@ func (c *Client) PortlandSearchOfficersByBadge(badge string) ([]*PortlandOfficer
 				helmet_id,
 				helmet_id_three_digit,
 				salary,
+				salary_amount,
 				badge,
 				cops_photo_profile_link,
 				cops_photo_has_photo,
-				employed_3_12_21,
-				employed_12_28_20,
-				employed_10_01_20,
-				retired_6_1_20,
+				s.last_roster_date,
+				s.is_current,
+				s.tenure_years,
 				retired_or_cert_revoked,
 				retired_or_cert_revoked_date,
 				hire_year,
@@ -319,7 +367,9 @@ func (c *Client) PortlandSearchOfficersByBadge(badge string) ([]*PortlandOfficer
 				instructed_for_less_lethal,
 				involved_in_ois_uof,
 				notes
-			FROM portland_search_officer_by_badge_p (badge := $1);
+			FROM portland_officers o
+			JOIN portland_roster_status s ON s.officer_id = o.id
+			WHERE badge_normalized = normalize_badge($1);
 		`,
 		badge,
 	)
@@ -332,48 +382,54 @@ func (c *Client) PortlandSearchOfficersByBadge(badge string) ([]*PortlandOfficer
 	return portlandMarshalOfficerRows(rows)
 }
 
-// PortlandGetOfficerByEmployeeId invokes portland_search_officer_by_employee_p
-func (c *Client) PortlandSearchOfficersByEmployeeId(employee_id string) ([]*PortlandOfficer, error) {
+// PortlandGetOfficerByBadgeHistorical returns an officer by their badge. It searches the
+// roster history and returns an entry for each roster the officer was employed on, in
+// descending date order.
+func (c *Client) PortlandGetOfficerByBadgeHistorical(badge string) ([]*PortlandOfficer, error) {
 	rows, err := c.pool.Query(context.Background(),
 		`
+			WITH max_roster AS (SELECT MAX(date) max_date FROM portland_roster_history)
 			SELECT
-				first_name,
-				last_name,
-				gender,
-				officer_rank,
-				employee_id,
-				helmet_id,
-				helmet_id_three_digit,
-				salary,
-				badge,
-				cops_photo_profile_link,
-				cops_photo_has_photo,
-				employed_3_12_21,
-				employed_12_28_20,
-				employed_10_01_20,
-				retired_6_1_20,
-				retired_or_cert_revoked,
-				retired_or_cert_revoked_date,
-				hire_year,
-				hire_date,
-				state_cert_date,
-				state_cert_level,
-				rrt,
-				rrt_2016,
-				rrt_2018_niiya_email,
-				rrt_2018,
-				rrt_2019,
-				rrt_2020,
-				sound_truck_training_2020,
-				instructed_for_dpsst,
-				instructed_for_less_lethal,
-				involved_in_ois_uof,
-				notes
-			FROM portland_search_officer_by_employee_p (employee_id := $1);
+				o.first_name,
+				o.last_name,
+				o.gender,
+				o.officer_rank,
+				o.employee_id,
+				o.helmet_id,
+				o.helmet_id_three_digit,
+				o.salary,
+				o.salary_amount,
+				o.badge,
+				o.cops_photo_profile_link,
+				o.cops_photo_has_photo,
+				r.date,
+				r.date = m.max_date is_current,
+				DATE_PART('year', AGE(r.date, o.hire_date))::INTEGER tenure_years,
+				o.retired_or_cert_revoked,
+				o.retired_or_cert_revoked_date,
+				o.hire_year,
+				o.hire_date,
+				o.state_cert_date,
+				o.state_cert_level,
+				o.rrt,
+				o.rrt_2016,
+				o.rrt_2018_niiya_email,
+				o.rrt_2018,
+				o.rrt_2019,
+				o.rrt_2020,
+				o.sound_truck_training_2020,
+				o.instructed_for_dpsst,
+				o.instructed_for_less_lethal,
+				o.involved_in_ois_uof,
+				o.notes
+			FROM portland_officers o
+			JOIN portland_roster r ON r.officer_id = o.id
+			CROSS JOIN max_roster m
+			WHERE o.badge_normalized = normalize_badge($1)
+			ORDER BY r.date DESC;
 		`,
-		employee_id,
+		badge,
 	)
-
 	if err != nil {
 		return nil, err
 	}
@@ -382,8 +438,10 @@ func (c *Client) PortlandSearchOfficersByEmployeeId(employee_id string) ([]*Port
 	return portlandMarshalOfficerRows(rows)
 }
 
-// PortlandGetOfficerByHelmetId invokes portland_search_officer_by_helmet_p
-func (c *Client) PortlandSearchOfficersByHelmetId(helmet_id string) ([]*PortlandOfficer, error) {
+// PortlandSearchOfficersById returns the officers with any identifier equal to id: their
+// badge (DPSST number, compared normalized), employee (chest) ID, helmet ID or three digit
+// helmet ID. Each officer lists the identifiers matched in MatchedIDs.
+func (c *Client) PortlandSearchOfficersById(id string) ([]*PortlandOfficer, error) {
 	rows, err := c.pool.Query(context.Background(),
 		`
 			SELECT
@@ -395,13 +453,13 @@ func (c *Client) PortlandSearchOfficersByHelmetId(helmet_id string) ([]*Portland
 				helmet_id,
 				helmet_id_three_digit,
 				salary,
+				salary_amount,
 				badge,
 				cops_photo_profile_link,
 				cops_photo_has_photo,
-				employed_3_12_21,
-				employed_12_28_20,
-				employed_10_01_20,
-				retired_6_1_20,
+				s.last_roster_date,
+				s.is_current,
+				s.tenure_years,
 				retired_or_cert_revoked,
 				retired_or_cert_revoked_date,
 				hire_year,
@@ -419,9 +477,14 @@ func (c *Client) PortlandSearchOfficersByHelmetId(helmet_id string) ([]*Portland
 				instructed_for_less_lethal,
 				involved_in_ois_uof,
 				notes
-			FROM portland_search_officer_by_helmet_p (helmet_id := $1);
+			FROM portland_officers o
+			JOIN portland_roster_status s ON s.officer_id = o.id
+			WHERE badge_normalized = NULLIF(normalize_badge($1), '')
+				OR employee_id = $1
+				OR helmet_id = $1
+				OR helmet_id_three_digit = $1;
 		`,
-		helmet_id,
+		id,
 	)
 
 	if err != nil {
@@ -429,11 +492,38 @@ func (c *Client) PortlandSearchOfficersByHelmetId(helmet_id string) ([]*Portland
 	}
 	defer rows.Close()
 
-	return portlandMarshalOfficerRows(rows)
+	officers, err := portlandMarshalOfficerRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	for _, ofc := range officers {
+		ofc.MatchedIDs = portlandMatchedIDs(ofc, id)
+	}
+	return officers, nil
 }
 
-// PortlandGetOfficerByHelmetIdThreeDigit invokes portland_search_officer_by_helmet_p
-func (c *Client) PortlandSearchOfficersByHelmetIdThreeDigit(helmet_id_three_digit string) ([]*PortlandOfficer, error) {
+// portlandMatchedIDs names the identifiers of ofc equal to id, compared the same way
+// PortlandSearchOfficersById compares them.
+func portlandMatchedIDs(ofc *PortlandOfficer, id string) []string {
+	matched := []string{}
+	if ofc.BadgeNormalized != "" && ofc.BadgeNormalized == NormalizeBadge(id) {
+		matched = append(matched, PortlandIDBadge)
+	}
+	if ofc.EmployeeID == id {
+		matched = append(matched, PortlandIDEmployeeID)
+	}
+	if ofc.HelmetID == id {
+		matched = append(matched, PortlandIDHelmetID)
+	}
+	if ofc.HelmetIDThreeDigit == id {
+		matched = append(matched, PortlandIDHelmetIDThreeDigit)
+	}
+	return matched
+}
+
+// PortlandSearchOfficersByEmployeeId returns the officers whose employee (chest) ID equals
+// employee_id.
+func (c *Client) PortlandSearchOfficersByEmployeeId(employee_id string) ([]*PortlandOfficer, error) {
 	rows, err := c.pool.Query(context.Background(),
 		`
 			SELECT
@@ -445,13 +535,13 @@ func (c *Client) PortlandSearchOfficersByHelmetIdThreeDigit(helmet_id_three_digi
 				helmet_id,
 				helmet_id_three_digit,
 				salary,
+				salary_amount,
 				badge,
 				cops_photo_profile_link,
 				cops_photo_has_photo,
-				employed_3_12_21,
-				employed_12_28_20,
-				employed_10_01_20,
-				retired_6_1_20,
+				s.last_roster_date,
+				s.is_current,
+				s.tenure_years,
 				retired_or_cert_revoked,
 				retired_or_cert_revoked_date,
 				hire_year,
@@ -469,9 +559,11 @@ func (c *Client) PortlandSearchOfficersByHelmetIdThreeDigit(helmet_id_three_digi
 				instructed_for_less_lethal,
 				involved_in_ois_uof,
 				notes
-			FROM portland_search_officer_by_helmet_three_digit_p (helmet_id_three_digit := $1);
+			FROM portland_officers o
+			JOIN portland_roster_status s ON s.officer_id = o.id
+			WHERE o.employee_id = $1;
 		`,
-		helmet_id_three_digit,
+		employee_id,
 	)
 
 	if err != nil {
@@ -482,8 +574,8 @@ func (c *Client) PortlandSearchOfficersByHelmetIdThreeDigit(helmet_id_three_digi
 	return portlandMarshalOfficerRows(rows)
 }
 
-// PortlandSearchOfficerByName invokes portland_search_officer_by_name_p
-func (c *Client) PortlandSearchOfficersByName(firstName, lastName string) ([]*PortlandOfficer, error) {
+// PortlandSearchOfficersByHelmetId returns the officers whose helmet ID equals helmet_id.
+func (c *Client) PortlandSearchOfficersByHelmetId(helmet_id string) ([]*PortlandOfficer, error) {
 	rows, err := c.pool.Query(context.Background(),
 		`
 			SELECT
@@ -495,13 +587,13 @@ func (c *Client) PortlandSearchOfficersByName(firstName, lastName string) ([]*Po
 				helmet_id,
 				helmet_id_three_digit,
 				salary,
+				salary_amount,
 				badge,
 				cops_photo_profile_link,
 				cops_photo_has_photo,
-				employed_3_12_21,
-				employed_12_28_20,
-				employed_10_01_20,
-				retired_6_1_20,
+				s.last_roster_date,
+				s.is_current,
+				s.tenure_years,
 				retired_or_cert_revoked,
 				retired_or_cert_revoked_date,
 				hire_year,
@@ -519,11 +611,13 @@ func (c *Client) PortlandSearchOfficersByName(firstName, lastName string) ([]*Po
 				instructed_for_less_lethal,
 				involved_in_ois_uof,
 				notes
-			FROM portland_search_officer_by_name_p(first_name := $1, last_name := $2);
+			FROM portland_officers o
+			JOIN portland_roster_status s ON s.officer_id = o.id
+			WHERE o.helmet_id = $1;
 		`,
-		firstName,
-		lastName,
+		helmet_id,
 	)
+
 	if err != nil {
 		return nil, err
 	}
@@ -532,8 +626,9 @@ func (c *Client) PortlandSearchOfficersByName(firstName, lastName string) ([]*Po
 	return portlandMarshalOfficerRows(rows)
 }
 
-// PortlandFuzzySearchByName invokes portland_fuzzy_search_officer_by_name_p
-func (c *Client) PortlandFuzzySearchByName(name string) ([]*PortlandOfficer, error) {
+// PortlandSearchOfficersByHelmetIdThreeDigit returns the officers whose three digit helmet
+// ID equals helmet_id_three_digit.
+func (c *Client) PortlandSearchOfficersByHelmetIdThreeDigit(helmet_id_three_digit string) ([]*PortlandOfficer, error) {
 	rows, err := c.pool.Query(context.Background(),
 		`
 			SELECT
@@ -545,13 +640,13 @@ func (c *Client) PortlandFuzzySearchByName(name string) ([]*PortlandOfficer, err
 				helmet_id,
 				helmet_id_three_digit,
 				salary,
+				salary_amount,
 				badge,
 				cops_photo_profile_link,
 				cops_photo_has_photo,
-				employed_3_12_21,
-				employed_12_28_20,
-				employed_10_01_20,
-				retired_6_1_20,
+				s.last_roster_date,
+				s.is_current,
+				s.tenure_years,
 				retired_or_cert_revoked,
 				retired_or_cert_revoked_date,
 				hire_year,
@@ -569,10 +664,13 @@ func (c *Client) PortlandFuzzySearchByName(name string) ([]*PortlandOfficer, err
 				instructed_for_less_lethal,
 				involved_in_ois_uof,
 				notes
-			FROM portland_fuzzy_search_officer_by_name_p(full_name_v := $1);
+			FROM portland_officers o
+			JOIN portland_roster_status s ON s.officer_id = o.id
+			WHERE o.helmet_id_three_digit = $1;
 		`,
-		name,
+		helmet_id_three_digit,
 	)
+
 	if err != nil {
 		return nil, err
 	}
@@ -581,10 +679,11 @@ func (c *Client) PortlandFuzzySearchByName(name string) ([]*PortlandOfficer, err
 	return portlandMarshalOfficerRows(rows)
 }
 
-// PortlandFuzzySearchByFirstName invokes portland_fuzzy_search_officer_by_first_name_p
-func (c *Client) PortlandFuzzySearchByFirstName(firstName string) ([]*PortlandOfficer, error) {
+// PortlandSearchOfficersByName returns an officer by their first or last name.
+func (c *Client) PortlandSearchOfficersByName(firstName, lastName string, nicknames []string, filters Filters) ([]*PortlandOfficer, error) {
+	conditions, args := filters.conditions(portlandFilterColumns, []interface{}{firstName, lastName, nicknames})
 	rows, err := c.pool.Query(context.Background(),
-		`
+		fmt.Sprintf(`
 			SELECT
 				first_name,
 				last_name,
@@ -594,13 +693,13 @@ func (c *Client) PortlandFuzzySearchByFirstName(firstName string) ([]*PortlandOf
 				helmet_id,
 				helmet_id_three_digit,
 				salary,
+				salary_amount,
 				badge,
 				cops_photo_profile_link,
 				cops_photo_has_photo,
-				employed_3_12_21,
-				employed_12_28_20,
-				employed_10_01_20,
-				retired_6_1_20,
+				s.last_roster_date,
+				s.is_current,
+				s.tenure_years,
 				retired_or_cert_revoked,
 				retired_or_cert_revoked_date,
 				hire_year,
@@ -618,58 +717,94 @@ func (c *Client) PortlandFuzzySearchByFirstName(firstName string) ([]*PortlandOf
 				instructed_for_less_lethal,
 				involved_in_ois_uof,
 				notes
-			FROM portland_fuzzy_search_officer_by_first_name_p(first_name := $1);
-		`,
-		firstName,
+			FROM portland_officers o
+			JOIN portland_roster_status s ON s.officer_id = o.id
+			WHERE (%s OR o.first_name_normalized = ANY($3)) AND %s%s;
+		`, filters.matchName("o.first_name", 1), filters.matchName("o.last_name", 2), conditions),
+		args...,
 	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	return portlandMarshalOfficerRows(rows)
+	return portlandMarshalOfficerRows(rows, nicknames...)
+}
+
+// PortlandSearchOfficersByFilters returns the officers matching the yes/no and range filters
+// of filters.
+func (c *Client) PortlandSearchOfficersByFilters(filters Filters) ([]*PortlandOfficer, error) {
+	return c.portlandListOfficers("TRUE", filters)
+}
+
+// PortlandSearchRRTOfficers returns the officers recorded as Rapid Response Team members in
+// year, one of PortlandRRTYears, or with training set, the officers who completed RRT specific
+// training in year, one of PortlandRRTTrainingYears. A year of 0 returns the officers recorded
+// as members, or as trained, in any year.
+func (c *Client) PortlandSearchRRTOfficers(year int, training bool, filters Filters) ([]*PortlandOfficer, error) {
+	years, byYear, columns := PortlandRRTYears, portlandRRTColumns, []string{"o.rrt"}
+	if training {
+		years, byYear, columns = PortlandRRTTrainingYears, portlandRRTTrainingColumns, []string{}
+	}
+	for _, y := range years {
+		columns = append(columns, byYear[y])
+	}
+	if year != 0 {
+		column, ok := byYear[year]
+		if !ok {
+			return nil, fmt.Errorf("no RRT records for %d", year)
+		}
+		columns = []string{column}
+	}
+	return c.portlandListOfficers(fmt.Sprintf("TRUE IN (%s)", strings.Join(columns, ", ")), filters)
 }
 
-// PortlandFuzzySearchByLastName invokes portland_fuzzy_search_officer_by_last_name_p
-func (c *Client) PortlandFuzzySearchByLastName(lastName string) ([]*PortlandOfficer, error) {
+// portlandListOfficers returns the officers matching condition along with filters.
+func (c *Client) portlandListOfficers(condition string, filters Filters) ([]*PortlandOfficer, error) {
+	conditions, args := filters.conditions(portlandFilterColumns, []interface{}{})
 	rows, err := c.pool.Query(context.Background(),
-		`
+		fmt.Sprintf(`
 			SELECT
-				first_name,
-				last_name,
-				gender,
-				officer_rank,
-				employee_id,
-				helmet_id,
-				helmet_id_three_digit,
-				salary,
-				badge,
-				cops_photo_profile_link,
-				cops_photo_has_photo,
-				employed_3_12_21,
-				employed_12_28_20,
-				employed_10_01_20,
-				retired_6_1_20,
-				retired_or_cert_revoked,
-				retired_or_cert_revoked_date,
-				hire_year,
-				hire_date,
-				state_cert_date,
-				state_cert_level,
-				rrt,
-				rrt_2016,
-				rrt_2018_niiya_email,
-				rrt_2018,
-				rrt_2019,
-				rrt_2020,
-				sound_truck_training_2020,
-				instructed_for_dpsst,
-				instructed_for_less_lethal,
-				involved_in_ois_uof,
-				notes
-			FROM portland_fuzzy_search_officer_by_last_name_p(last_name := $1);
-		`,
-		lastName,
+				o.first_name,
+				o.last_name,
+				o.gender,
+				o.officer_rank,
+				o.employee_id,
+				o.helmet_id,
+				o.helmet_id_three_digit,
+				o.salary,
+				o.salary_amount,
+				o.badge,
+				o.cops_photo_profile_link,
+				o.cops_photo_has_photo,
+				s.last_roster_date,
+				s.is_current,
+				s.tenure_years,
+				o.retired_or_cert_revoked,
+				o.retired_or_cert_revoked_date,
+				o.hire_year,
+				o.hire_date,
+				o.state_cert_date,
+				o.state_cert_level,
+				o.rrt,
+				o.rrt_2016,
+				o.rrt_2018_niiya_email,
+				o.rrt_2018,
+				o.rrt_2019,
+				o.rrt_2020,
+				o.sound_truck_training_2020,
+				o.instructed_for_dpsst,
+				o.instructed_for_less_lethal,
+				o.involved_in_ois_uof,
+				o.notes
+			FROM portland_officers o
+			JOIN portland_roster_status s ON s.officer_id = o.id
+			WHERE %s%s
+			ORDER BY
+				o.last_name,
+				o.first_name;
+		`, condition, conditions),
+		args...,
 	)
 	if err != nil {
 		return nil, err
@@ -679,10 +814,94 @@ func (c *Client) PortlandFuzzySearchByLastName(lastName string) ([]*PortlandOffi
 	return portlandMarshalOfficerRows(rows)
 }
 
-func portlandMarshalOfficerRows(rows pgx.Rows) ([]*PortlandOfficer, error) {
+// PortlandFuzzySearchByName returns an list of officers by their full name using fuzzy matching.
+// Entries are sorted by how well they match the full name in descending order.
+func (c *Client) PortlandFuzzySearchByName(name string, opts FuzzyOptions) ([]*PortlandOfficer, error) {
+	return c.portlandFuzzySearch(fuzzyFullName, name, opts)
+}
+
+// PortlandFuzzySearchByFirstName returns an list of officers by their first name using fuzzy matching.
+// Entries are sorted by how well they match the first name in descending order.
+func (c *Client) PortlandFuzzySearchByFirstName(firstName string, opts FuzzyOptions) ([]*PortlandOfficer, error) {
+	return c.portlandFuzzySearch(fuzzyFirstName, firstName, opts)
+}
+
+// PortlandFuzzySearchByLastName returns an list of officers by their last name using fuzzy matching.
+// Entries are sorted by how well they match the last name in descending order.
+func (c *Client) PortlandFuzzySearchByLastName(lastName string, opts FuzzyOptions) ([]*PortlandOfficer, error) {
+	return c.portlandFuzzySearch(fuzzyLastName, lastName, opts)
+}
+
+// portlandFuzzySearch returns the officers whose name matches value, using the matching
+// requested through opts.
+func (c *Client) portlandFuzzySearch(name fuzzyName, value string, opts FuzzyOptions) ([]*PortlandOfficer, error) {
+	match := name.match(value, opts)
+	rows, err := c.pool.Query(context.Background(),
+		fmt.Sprintf(`
+			SELECT
+				o.first_name,
+				o.last_name,
+				o.gender,
+				o.officer_rank,
+				o.employee_id,
+				o.helmet_id,
+				o.helmet_id_three_digit,
+				o.salary,
+				o.salary_amount,
+				o.badge,
+				o.cops_photo_profile_link,
+				o.cops_photo_has_photo,
+				s.last_roster_date,
+				s.is_current,
+				s.tenure_years,
+				o.retired_or_cert_revoked,
+				o.retired_or_cert_revoked_date,
+				o.hire_year,
+				o.hire_date,
+				o.state_cert_date,
+				o.state_cert_level,
+				o.rrt,
+				o.rrt_2016,
+				o.rrt_2018_niiya_email,
+				o.rrt_2018,
+				o.rrt_2019,
+				o.rrt_2020,
+				o.sound_truck_training_2020,
+				o.instructed_for_dpsst,
+				o.instructed_for_less_lethal,
+				o.involved_in_ois_uof,
+				o.notes,
+				%[2]s match_score,
+				%[3]s matched_on
+			FROM portland_officers o
+			JOIN portland_roster_status s ON s.officer_id = o.id
+			WHERE %[1]s
+			ORDER BY match_score DESC;
+		`, match.condition, match.score, match.matchedOn),
+		match.args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	fuzzy := &fuzzyRows{Rows: rows}
+	officers, err := portlandMarshalOfficerRows(fuzzy, opts.Nicknames...)
+	if err != nil {
+		return nil, err
+	}
+	for i, ofc := range officers {
+		ofc.MatchScore, ofc.MatchedOn = fuzzy.matches[i].MatchScore, fuzzy.matches[i].MatchedOn
+	}
+	return officers, nil
+}
+
+// portlandMarshalOfficerRows takes SQL return objects and marshals them onto the
+// PortlandOfficer object for return as JSON by the API.
+func portlandMarshalOfficerRows(rows pgx.Rows, nicknames ...string) ([]*PortlandOfficer, error) {
 	officers := []*PortlandOfficer{}
 	for rows.Next() {
-		ofc := PortlandOfficer{}
+		ofc := portlandOfficer{}
 		err := rows.Scan(
 			&ofc.FirstName,
 			&ofc.LastName,
@@ -692,13 +911,13 @@ func portlandMarshalOfficerRows(rows pgx.Rows) ([]*PortlandOfficer, error) {
 			&ofc.HelmetID,
 			&ofc.HelmetIDThreeDigit,
 			&ofc.Salary,
+			&ofc.SalaryAmount,
 			&ofc.Badge,
 			&ofc.CopsPhotoProfileLink,
 			&ofc.CopsPhotoHasPhoto,
-			&ofc.Employed_3_12_21,
-			&ofc.Employed_12_28_20,
-			&ofc.Employed_10_01_20,
-			&ofc.Retired_6_1_20,
+			&ofc.Date,
+			&ofc.Current,
+			&ofc.TenureYears,
 			&ofc.RetiredOrCertRevoked,
 			&ofc.RetiredOrCertRevokedDate,
 			&ofc.HireYear,
@@ -721,7 +940,95 @@ func portlandMarshalOfficerRows(rows pgx.Rows) ([]*PortlandOfficer, error) {
 		if err != nil {
 			return nil, err
 		}
-		officers = append(officers, &ofc)
+
+		returnOfficer := PortlandOfficer{
+			ofc.FirstName.String,
+			ofc.LastName.String,
+			ofc.Gender.String,
+			ofc.OfficerRank.String,
+			CanonicalRank(ofc.OfficerRank.String),
+			ofc.EmployeeID.String,
+			ofc.HelmetID.String,
+			ofc.HelmetIDThreeDigit.String,
+			ofc.Salary.String,
+			nullFloat(ofc.SalaryAmount),
+			ofc.Badge.String,
+			NormalizeBadge(ofc.Badge.String),
+			ofc.CopsPhotoProfileLink.String,
+			nullBool(ofc.CopsPhotoHasPhoto),
+			nullDate(ofc.Date),
+			ofc.Current,
+			nullInt(ofc.TenureYears),
+			nullBool(ofc.RetiredOrCertRevoked),
+			nullDate(ofc.RetiredOrCertRevokedDate),
+			ofc.HireYear.Int,
+			nullDate(ofc.HireDate),
+			nullDate(ofc.StateCertDate),
+			ofc.StateCertLevel.String,
+			nullBool(ofc.RRT),
+			nullBool(ofc.RRT2016),
+			nullBool(ofc.RRT2018NiiyaEmail),
+			nullBool(ofc.RRT2018),
+			nullBool(ofc.RRT2019),
+			nullBool(ofc.RRT2020),
+			nullBool(ofc.SoundTruckTraining),
+			nullBool(ofc.InstructedForDpsst),
+			nullBool(ofc.InstructedForLessLethal),
+			nullBool(ofc.InvolvedInOisUof),
+			ofc.Notes.String,
+			nil,
+			NameMatch{MatchedNickname: matchedNickname(ofc.FirstName.String, nicknames)},
+		}
+		officers = append(officers, &returnOfficer)
 	}
 	return officers, nil
 }
+
+// nullBool returns a pointer to the value of b, or nil when b is NULL
+func nullBool(b nulls.Bool) *bool {
+	if !b.Valid {
+		return nil
+	}
+	return &b.Bool
+}
+
+// nullInt returns a pointer to the value of i, or nil when i is NULL
+func nullInt(i nulls.Int) *int {
+	if !i.Valid {
+		return nil
+	}
+	return &i.Int
+}
+
+// nullFloat returns a pointer to the value of f, or nil when f is NULL
+func nullFloat(f nulls.Float64) *float64 {
+	if !f.Valid {
+		return nil
+	}
+	return &f.Float64
+}
+
+// nullDate formats t as YYYY-MM-DD, or returns an empty string when t is NULL
+func nullDate(t nulls.Time) string {
+	if !t.Valid {
+		return ""
+	}
+	return t.Time.Format("2006-01-02")
+}
+
+// PortlandHeadcountByDate returns the number of officers employed on each roster date,
+// optionally grouped by title.
+func (c *Client) PortlandHeadcountByDate(groupBy string) ([]*HeadcountStat, error) {
+	return c.headcountByDate("portland_roster", groupBy)
+}
+
+// PortlandSalaryStats summarizes the 2019 fiscal earnings of officers, optionally grouped by title.
+func (c *Client) PortlandSalaryStats(groupBy string) ([]*SalaryStat, error) {
+	return c.salaryStats("portland_officers", "o.salary_amount", portlandSalaryGroupColumns, groupBy)
+}
+
+// PortlandAutocomplete suggests up to limit officer names starting with prefix, along with each
+// officer's badge and title.
+func (c *Client) PortlandAutocomplete(prefix string, limit int) ([]*OfficerSuggestion, error) {
+	return c.autocomplete(namedAutocompleteSource("portland_officers", "o.badge", "o.officer_rank", ""), prefix, limit)
+}
@@ -0,0 +1,63 @@
+package data
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Canonical ranks shared across departments
+const (
+	RankOfficer    = "officer"
+	RankDetective  = "detective"
+	RankSergeant   = "sergeant"
+	RankLieutenant = "lieutenant"
+	RankCaptain    = "captain"
+	RankCommand    = "command"
+	RankCivilian   = "civilian"
+)
+
+// CanonicalRanks lists the canonical ranks from least to most senior, with civilian staff last
+var CanonicalRanks = []string{
+	RankOfficer,
+	RankDetective,
+	RankSergeant,
+	RankLieutenant,
+	RankCaptain,
+	RankCommand,
+	RankCivilian,
+}
+
+// canonicalRankPatterns maps free text titles onto canonical ranks. Titles are matched
+// against each pattern in turn, so civilian roles whose titles contain a sworn keyword
+// (e.g. "Community Service Officer") and senior titles containing a junior title's
+// keyword (e.g. "Police Chief Asst", "Sergeant-Detective") must come first. "Sheriff" also
+// appears in the titles of a sheriff's deputies and line ranks (e.g. "Deputy Sheriff"), so
+// only the sheriff and undersheriff themselves are matched on it.
+var canonicalRankPatterns = []struct {
+	pattern *regexp.Regexp
+	rank    string
+}{
+	{regexp.MustCompile(`(?i)community service|parking|animal control|crime prevention|evidence|records|dispatch`), RankCivilian},
+	{regexp.MustCompile(`(?i)^\s*(under)?sheriff\s*$`), RankCommand},
+	{regexp.MustCompile(`(?i)\bchief\b|\bcommander\b|\bmajor\b`), RankCommand},
+	{regexp.MustCompile(`(?i)\bcaptain\b|\bcapt\b`), RankCaptain},
+	{regexp.MustCompile(`(?i)\blieutenant\b|\blieut\b|\blt\b`), RankLieutenant},
+	{regexp.MustCompile(`(?i)\bsergeant\b|\bsgt\b`), RankSergeant},
+	{regexp.MustCompile(`(?i)\bdetective\b|\bdet\b`), RankDetective},
+	{regexp.MustCompile(`(?i)\bofficer\b|\bofcr\b|\bdeputy\b|\bcorporal\b|\bcpl\b|\bpatrol\b|\btrooper\b|\brecruit\b`), RankOfficer},
+}
+
+// CanonicalRank maps a department's free text title or rank onto one of the canonical
+// ranks. Titles without a sworn rank are treated as civilian staff; an empty title
+// returns an empty rank.
+func CanonicalRank(title string) string {
+	if strings.TrimSpace(title) == "" {
+		return ""
+	}
+	for _, p := range canonicalRankPatterns {
+		if p.pattern.MatchString(title) {
+			return p.rank
+		}
+	}
+	return RankCivilian
+}
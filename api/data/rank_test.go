@@ -0,0 +1,23 @@
+package data
+
+import "testing"
+
+func TestCanonicalRank(t *testing.T) {
+	for _, tt := range []struct {
+		title string
+		rank  string
+	}{
+		{"", ""},
+		{"Deputy Sheriff", RankOfficer},
+		{"Sheriff Sergeant", RankSergeant},
+		{"Sheriff", RankCommand},
+		{"Undersheriff", RankCommand},
+		{"Chief Deputy", RankCommand},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			if rank := CanonicalRank(tt.title); rank != tt.rank {
+				t.Errorf("CanonicalRank(%q) = %q, want %q", tt.title, rank, tt.rank)
+			}
+		})
+	}
+}
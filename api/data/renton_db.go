@@ -2,6 +2,7 @@ package data
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/gobuffalo/nulls"
 	"github.com/jackc/pgx/v4"
@@ -9,15 +10,18 @@ import (
 
 // RentonOfficer is the object model for LPD officers
 type RentonOfficer struct {
-	LastName       string `json:"last_name,omitempty"`
-	FirstName      string `json:"first_name,omitempty"`
-	MiddleName     string `json:"middle_name,omitempty"`
-	Rank           string `json:"rank,omitempty"`
-	Department     string `json:"department,omitempty"`
-	Division       string `json:"division,omitempty"`
-	Shift          string `json:"shift,omitempty"`
-	AdditionalInfo string `json:"additional_info,omitempty"`
-	Badge          string `json:"badge,omitempty"`
+	LastName        string `json:"last_name,omitempty"`
+	FirstName       string `json:"first_name,omitempty"`
+	MiddleName      string `json:"middle_name,omitempty"`
+	Rank            string `json:"rank,omitempty"`
+	CanonicalRank   string `json:"canonical_rank,omitempty"`
+	Department      string `json:"department,omitempty"`
+	Division        string `json:"division,omitempty"`
+	Shift           string `json:"shift,omitempty"`
+	AdditionalInfo  string `json:"additional_info,omitempty"`
+	Badge           string `json:"badge,omitempty"`
+	BadgeNormalized string `json:"badge_normalized,omitempty"`
+	NameMatch
 }
 
 // rentonOfficer is an internal intermediary between the returned SQL rows data
@@ -34,6 +38,19 @@ type rentonOfficer struct {
 	Badge          nulls.String
 }
 
+// rentonFuzzyFullName describes the full name, middle name included, searched by Renton fuzzy searches
+var rentonFuzzyFullName = fuzzyName{"normalize_name(CONCAT_WS(' ', o.first_name, o.middle_name, o.last_name))", "phonetic_codes(CONCAT_WS(' ', o.first_name, o.middle_name, o.last_name))", MatchedOnFullName, nil}
+
+// rentonFilterColumns maps the filters supported by Renton strict searches onto their columns
+var rentonFilterColumns = map[string]string{
+	"title":       "o.rank",
+	"department":  "o.department",
+	"division":    "o.division",
+	"shift":       "o.shift",
+	"middle_name": "o.middle_name",
+	"full_name":   "CONCAT_WS(' ', o.first_name, o.middle_name, o.last_name)",
+}
+
 // RentonOfficerMetadata retrieves metadata describing the RentonOfficer struct
 func (c *Client) RentonOfficerMetadata() *DepartmentMetadata {
 	return &DepartmentMetadata{
@@ -54,6 +71,10 @@ func (c *Client) RentonOfficerMetadata() *DepartmentMetadata {
 				"FieldName": "rank",
 				"Label":     "Officer Rank",
 			},
+			{
+				"FieldName": "canonical_rank",
+				"Label":     "Canonical Rank",
+			},
 			{
 				"FieldName": "department",
 				"Label":     "Officer Department",
@@ -81,20 +102,26 @@ func (c *Client) RentonOfficerMetadata() *DepartmentMetadata {
 		SearchRoutes: map[string]*SearchRouteMetadata{
 			"exact": {
 				Path:        "/renton/officer",
-				QueryParams: []string{"first_name", "last_name"},
+				QueryParams: []string{"q", "badge", "badge_mode", "first_name", "middle_name", "last_name", "full_name", "nicknames", "title", "department", "division", "shift", "mode", "rank"},
 			},
 			"fuzzy": {
 				Path:        "/renton/officer/search",
-				QueryParams: []string{"first_name", "last_name"},
+				QueryParams: []string{"q", "first_name", "middle_name", "last_name", "full_name", "nicknames", "mode", "min_score", "threshold", "rank"},
+			},
+			"autocomplete": {
+				Path:        "/renton/officer/autocomplete",
+				QueryParams: []string{"prefix", "limit"},
 			},
 		},
 	}
 }
 
-// RentonSearchOfficerByName returns an officer by their first or last name.
-func (c *Client) RentonSearchOfficerByName(firstName, lastName string) ([]*RentonOfficer, error) {
+// RentonSearchOfficerByBadge returns the officers matching a badge search, ranked by how
+// close their badge is to the badge searched.
+func (c *Client) RentonSearchOfficerByBadge(badge BadgeSearch) ([]*RentonOfficer, error) {
+	condition, distance, args := badge.match("o.badge_normalized")
 	rows, err := c.pool.Query(context.Background(),
-		`
+		fmt.Sprintf(`
 			SELECT
 				o.last_name,
 				o.first_name,
@@ -103,16 +130,16 @@ func (c *Client) RentonSearchOfficerByName(firstName, lastName string) ([]*Rento
 				o.department,
 				o.division,
 				o.shift,
-                o.additional_info,
-                o.badge_number
+				o.additional_info,
+				o.badge_number
 			FROM renton_officers o
-			WHERE LOWER(o.first_name) LIKE LOWER($1) AND LOWER(o.last_name) LIKE LOWER($2)
-			ORDER BY 
+			WHERE %s
+			ORDER BY
+				%s,
 				o.last_name,
 				o.first_name;
-		`,
-		firstName,
-		lastName,
+		`, condition, distance),
+		args...,
 	)
 	if err != nil {
 		return nil, err
@@ -122,11 +149,11 @@ func (c *Client) RentonSearchOfficerByName(firstName, lastName string) ([]*Rento
 	return rentonMarshalOfficerRows(rows)
 }
 
-// RentonFuzzySearchByName returns an list of officers by their full name using fuzzy matching.
-// Entries are sorted by similarity to the full name in descending order.
-func (c *Client) RentonFuzzySearchByName(name string) ([]*RentonOfficer, error) {
+// RentonSearchOfficerByName returns an officer by their first or last name.
+func (c *Client) RentonSearchOfficerByName(firstName, lastName string, nicknames []string, filters Filters) ([]*RentonOfficer, error) {
+	conditions, args := filters.conditions(rentonFilterColumns, []interface{}{firstName, lastName, nicknames})
 	rows, err := c.pool.Query(context.Background(),
-		`
+		fmt.Sprintf(`
 			SELECT
 				o.last_name,
 				o.first_name,
@@ -138,56 +165,51 @@ func (c *Client) RentonFuzzySearchByName(name string) ([]*RentonOfficer, error)
                 o.additional_info,
                 o.badge_number
 			FROM renton_officers o
-			WHERE LOWER(first_name || ' ' || last_name) % LOWER($1)
-			ORDER BY
-				SIMILARITY(LOWER(o.first_name || ' ' || o.last_name), LOWER($1)) DESC;
-		`,
-		name,
+			WHERE (%s OR o.first_name_normalized = ANY($3)) AND %s%s
+			ORDER BY 
+				o.last_name,
+				o.first_name;
+		`, filters.matchName("o.first_name", 1), filters.matchName("o.last_name", 2), conditions),
+		args...,
 	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	return rentonMarshalOfficerRows(rows)
+	return rentonMarshalOfficerRows(rows, nicknames...)
 }
 
-// RentonFuzzySearchByFirstName returns an list of officers by their first name using fuzzy matching.
-// Entries are sorted by similarity to the full name in descending order.
-func (c *Client) RentonFuzzySearchByFirstName(firstName string) ([]*RentonOfficer, error) {
-	rows, err := c.pool.Query(context.Background(),
-		`
-			SELECT
-				o.last_name,
-				o.first_name,
-				o.middle_name,
-				o.rank,
-				o.department,
-				o.division,
-				o.shift,
-                o.additional_info,
-                o.badge_number
+// RentonFuzzySearchByName returns an list of officers by their full name using fuzzy matching.
+// Entries are sorted by how well they match the full name in descending order.
+func (c *Client) RentonFuzzySearchByName(name string, opts FuzzyOptions) ([]*RentonOfficer, error) {
+	return c.rentonFuzzySearch(fuzzyFullName, name, opts)
+}
 
-			FROM renton_officers o
-			WHERE LOWER(first_name) % LOWER($1)
-			ORDER BY
-				SIMILARITY(LOWER(o.first_name), LOWER($1)) DESC;
-		`,
-		firstName,
-	)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
+// RentonFuzzySearchByFullName returns an list of officers by their full name, middle name included,
+// using fuzzy matching.
+func (c *Client) RentonFuzzySearchByFullName(fullName string, opts FuzzyOptions) ([]*RentonOfficer, error) {
+	return c.rentonFuzzySearch(rentonFuzzyFullName, fullName, opts)
+}
 
-	return rentonMarshalOfficerRows(rows)
+// RentonFuzzySearchByFirstName returns an list of officers by their first name using fuzzy matching.
+// Entries are sorted by how well they match the first name in descending order.
+func (c *Client) RentonFuzzySearchByFirstName(firstName string, opts FuzzyOptions) ([]*RentonOfficer, error) {
+	return c.rentonFuzzySearch(fuzzyFirstName, firstName, opts)
 }
 
 // RentonFuzzySearchByLastName returns an list of officers by their last name using fuzzy matching.
-// Entries are sorted by similarity to the full name in descending order.
-func (c *Client) RentonFuzzySearchByLastName(lastName string) ([]*RentonOfficer, error) {
+// Entries are sorted by how well they match the last name in descending order.
+func (c *Client) RentonFuzzySearchByLastName(lastName string, opts FuzzyOptions) ([]*RentonOfficer, error) {
+	return c.rentonFuzzySearch(fuzzyLastName, lastName, opts)
+}
+
+// rentonFuzzySearch returns the officers whose name matches value, using the matching
+// requested through opts.
+func (c *Client) rentonFuzzySearch(name fuzzyName, value string, opts FuzzyOptions) ([]*RentonOfficer, error) {
+	match := name.match(value, opts).withMiddleName("o.middle_name", opts.MiddleName)
 	rows, err := c.pool.Query(context.Background(),
-		`
+		fmt.Sprintf(`
 			SELECT
 				o.last_name,
 				o.first_name,
@@ -196,27 +218,35 @@ func (c *Client) RentonFuzzySearchByLastName(lastName string) ([]*RentonOfficer,
 				o.department,
 				o.division,
 				o.shift,
-                o.additional_info,
-                o.badge_number
-
+				o.additional_info,
+				o.badge_number,
+				%[2]s match_score,
+				%[3]s matched_on
 			FROM renton_officers o
-			WHERE LOWER(last_name) % LOWER($1)
-			ORDER BY
-				SIMILARITY(LOWER(o.last_name), LOWER($1)) DESC;
-		`,
-		lastName,
+			WHERE %[1]s
+			ORDER BY match_score DESC;
+		`, match.condition, match.score, match.matchedOn),
+		match.args...,
 	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	return rentonMarshalOfficerRows(rows)
+	fuzzy := &fuzzyRows{Rows: rows}
+	officers, err := rentonMarshalOfficerRows(fuzzy, opts.Nicknames...)
+	if err != nil {
+		return nil, err
+	}
+	for i, ofc := range officers {
+		ofc.MatchScore, ofc.MatchedOn = fuzzy.matches[i].MatchScore, fuzzy.matches[i].MatchedOn
+	}
+	return officers, nil
 }
 
 // rentonMarshalOfficerRows takes SQL return objects and marshals them onto the
 // RentonOfficer object for return as JSON by the API.
-func rentonMarshalOfficerRows(rows pgx.Rows) ([]*RentonOfficer, error) {
+func rentonMarshalOfficerRows(rows pgx.Rows, nicknames ...string) ([]*RentonOfficer, error) {
 	officers := []*RentonOfficer{}
 	for rows.Next() {
 		ofc := rentonOfficer{}
@@ -241,14 +271,23 @@ func rentonMarshalOfficerRows(rows pgx.Rows) ([]*RentonOfficer, error) {
 			ofc.FirstName.String,
 			ofc.MiddleName.String,
 			ofc.Rank.String,
+			CanonicalRank(ofc.Rank.String),
 			ofc.Department.String,
 			ofc.Division.String,
 			ofc.Shift.String,
 			ofc.AdditionalInfo.String,
 			ofc.Badge.String,
+			NormalizeBadge(ofc.Badge.String),
+			NameMatch{MatchedNickname: matchedNickname(ofc.FirstName.String, nicknames)},
 		}
 
 		officers = append(officers, &returnOfficer)
 	}
 	return officers, nil
 }
+
+// RentonAutocomplete suggests up to limit officer names starting with prefix, along with each
+// officer's badge and title.
+func (c *Client) RentonAutocomplete(prefix string, limit int) ([]*OfficerSuggestion, error) {
+	return c.autocomplete(namedAutocompleteSource("renton_officers", "o.badge_number", "o.rank", ""), prefix, limit)
+}
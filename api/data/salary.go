@@ -0,0 +1,79 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// SalaryStat summarizes the salaries of a group of officers, such as every officer sharing
+// a title. Officers without a recorded salary are left out.
+type SalaryStat struct {
+	Group  string  `json:"group,omitempty"`
+	Count  int     `json:"count"`
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+	Mean   float64 `json:"mean"`
+	Median float64 `json:"median"`
+	P25    float64 `json:"p25"`
+	P75    float64 `json:"p75"`
+	P90    float64 `json:"p90"`
+}
+
+// salaryStats summarizes the salaries stored in the salary column of table. If groupBy is
+// provided the salaries are broken down by the column groupColumns maps it onto.
+func (c *Client) salaryStats(table, salary string, groupColumns map[string]string, groupBy string) ([]*SalaryStat, error) {
+	group := "''"
+	if groupBy != "" {
+		column, ok := groupColumns[groupBy]
+		if !ok {
+			return nil, fmt.Errorf("unsupported group_by: %s", groupBy)
+		}
+		group = fmt.Sprintf("COALESCE(%s, '')", column)
+	}
+
+	rows, err := c.pool.Query(context.Background(),
+		fmt.Sprintf(`
+			SELECT
+				%[1]s grp,
+				COUNT(*) officers,
+				MIN(%[2]s)::FLOAT8,
+				MAX(%[2]s)::FLOAT8,
+				AVG(%[2]s)::FLOAT8,
+				PERCENTILE_CONT(ARRAY[0.5, 0.25, 0.75, 0.9]) WITHIN GROUP (ORDER BY %[2]s::FLOAT8)
+			FROM %[3]s o
+			WHERE %[2]s IS NOT NULL
+			GROUP BY %[1]s
+			ORDER BY
+				officers DESC,
+				grp;
+		`, group, salary, table),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats := []*SalaryStat{}
+	for rows.Next() {
+		var percentiles []float64
+		stat := SalaryStat{}
+		err := rows.Scan(
+			&stat.Group,
+			&stat.Count,
+			&stat.Min,
+			&stat.Max,
+			&stat.Mean,
+			&percentiles,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		stat.Mean = math.Round(stat.Mean*100) / 100
+		stat.Median, stat.P25, stat.P75, stat.P90 = percentiles[0], percentiles[1], percentiles[2], percentiles[3]
+		stats = append(stats, &stat)
+	}
+	return stats, nil
+}
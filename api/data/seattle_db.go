@@ -2,7 +2,9 @@ package data
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"regexp"
 	"time"
 
 	"github.com/gobuffalo/nulls"
@@ -13,14 +15,17 @@ import (
 type SeattleOfficer struct {
 	Date            string `json:"date,omitempty"`
 	Badge           string `json:"badge,omitempty"`
+	BadgeNormalized string `json:"badge_normalized,omitempty"`
 	FullName        string `json:"full_name,omitempty"`
 	Title           string `json:"title,omitempty"`
+	CanonicalRank   string `json:"canonical_rank,omitempty"`
 	Unit            string `json:"unit,omitempty"`
 	UnitDescription string `json:"unit_description,omitempty"`
 	FirstName       string `json:"first_name,omitempty"`
 	MiddleName      string `json:"middle_name,omitempty"`
 	LastName        string `json:"last_name,omitempty"`
 	Current         bool   `json:"is_current"`
+	NameMatch
 }
 
 // seattleOfficer is an internal intermediary between the returned SQL rows data
@@ -38,6 +43,120 @@ type seattleOfficer struct {
 	Current         bool
 }
 
+// SeattleTitleChange describes an SPD officer whose rank changed between two consecutive
+// rosters they appear on.
+type SeattleTitleChange struct {
+	Date            string `json:"date"`
+	PreviousDate    string `json:"previous_date"`
+	Badge           string `json:"badge,omitempty"`
+	FullName        string `json:"full_name,omitempty"`
+	FirstName       string `json:"first_name,omitempty"`
+	MiddleName      string `json:"middle_name,omitempty"`
+	LastName        string `json:"last_name,omitempty"`
+	PreviousTitle   string `json:"previous_title"`
+	Title           string `json:"title"`
+	Unit            string `json:"unit,omitempty"`
+	UnitDescription string `json:"unit_description,omitempty"`
+	Change          string `json:"change"`
+}
+
+// Classifications of a SeattleTitleChange
+const (
+	TitleChangePromotion   = "promotion"
+	TitleChangeDemotion    = "demotion"
+	TitleChangeActing      = "acting"
+	TitleChangeActingEnded = "acting_ended"
+)
+
+// seattleActingTitle matches temporary titles such as "Actg Police Sergeant" or
+// "Interim Chief Of Police"
+var seattleActingTitle = regexp.MustCompile(`(?i)\b(acting|actg|interim)\b`)
+
+// seattleTitleLevels orders SPD titles by seniority. Titles are matched against each
+// pattern in turn, so more senior titles containing a junior title's keyword must
+// come first (e.g. "Police Sergeant-Detective").
+var seattleTitleLevels = []struct {
+	pattern *regexp.Regexp
+	level   int
+}{
+	{regexp.MustCompile(`(?i)\bdeputy chief\b`), 7},
+	{regexp.MustCompile(`(?i)\b(asst|assistant) chief\b`), 6},
+	{regexp.MustCompile(`(?i)\bchief\b`), 8},
+	{regexp.MustCompile(`(?i)\bcaptain\b|\bcapt\b`), 5},
+	{regexp.MustCompile(`(?i)\blieutenant\b|\blt\b`), 4},
+	{regexp.MustCompile(`(?i)\bsergeant\b|\bsgt\b`), 3},
+	{regexp.MustCompile(`(?i)\bdetective\b|\bdet\b`), 2},
+	{regexp.MustCompile(`(?i)\bofficer\b|\bofcr\b`), 1},
+}
+
+// seattleTitleLevel returns the seniority of a title, or 0 for titles that are not sworn ranks
+func seattleTitleLevel(title string) int {
+	for _, l := range seattleTitleLevels {
+		if l.pattern.MatchString(title) {
+			return l.level
+		}
+	}
+	return 0
+}
+
+// seattleClassifyTitleChange classifies the move from one title to another. An empty
+// classification is returned when the titles share the same rank.
+func seattleClassifyTitleChange(previousTitle, title string) string {
+	previousLevel, level := seattleTitleLevel(previousTitle), seattleTitleLevel(title)
+	if previousLevel == 0 || level == 0 {
+		return ""
+	}
+
+	previousActing, acting := seattleActingTitle.MatchString(previousTitle), seattleActingTitle.MatchString(title)
+	switch {
+	case acting && (!previousActing || level > previousLevel):
+		return TitleChangeActing
+	case level > previousLevel:
+		return TitleChangePromotion
+	case level < previousLevel && previousActing:
+		return TitleChangeActingEnded
+	case level < previousLevel:
+		return TitleChangeDemotion
+	case previousActing && !acting:
+		// an acting rank was made permanent
+		return TitleChangePromotion
+	default:
+		return ""
+	}
+}
+
+// SeattlePrecinctCollection is a GeoJSON FeatureCollection of SPD precincts
+type SeattlePrecinctCollection struct {
+	Type     string                    `json:"type"`
+	Features []*SeattlePrecinctFeature `json:"features"`
+}
+
+// SeattlePrecinctFeature is a GeoJSON Feature describing a single SPD precinct
+type SeattlePrecinctFeature struct {
+	Type       string                     `json:"type"`
+	Geometry   json.RawMessage            `json:"geometry"`
+	Properties *SeattlePrecinctProperties `json:"properties"`
+}
+
+// SeattlePrecinctProperties are the properties attached to each precinct feature
+type SeattlePrecinctProperties struct {
+	Name      string   `json:"name"`
+	Headcount int      `json:"headcount"`
+	Units     []string `json:"units"`
+}
+
+// seattleFuzzyFullName describes the full name, middle name included, searched by Seattle fuzzy searches
+var seattleFuzzyFullName = fuzzyName{"normalize_name(o.full_name)", "phonetic_codes(o.full_name)", MatchedOnFullName, nil}
+
+// seattleFilterColumns maps the filters supported by Seattle strict searches onto their columns
+var seattleFilterColumns = map[string]string{
+	"title":            "o.title",
+	"unit":             "o.unit",
+	"unit_description": "o.unit_description",
+	"middle_name":      "o.middle_name",
+	"full_name":        "o.full_name",
+}
+
 // SeattleOfficerMetadata retrieves metadata describing the SeattleOfficer struct
 func (c *Client) SeattleOfficerMetadata() *DepartmentMetadata {
 	var date time.Time
@@ -78,6 +197,10 @@ func (c *Client) SeattleOfficerMetadata() *DepartmentMetadata {
 				"FieldName": "title",
 				"Label":     "Title",
 			},
+			{
+				"FieldName": "canonical_rank",
+				"Label":     "Canonical Rank",
+			},
 			{
 				"FieldName": "unit",
 				"Label":     "Unit",
@@ -101,26 +224,40 @@ func (c *Client) SeattleOfficerMetadata() *DepartmentMetadata {
 		SearchRoutes: map[string]*SearchRouteMetadata{
 			"exact": {
 				Path:        "/seattle/officer",
-				QueryParams: []string{"badge", "first_name", "last_name"},
+				QueryParams: []string{"q", "badge", "badge_mode", "first_name", "middle_name", "last_name", "full_name", "nicknames", "title", "unit", "unit_description", "mode", "rank"},
 			},
 			"fuzzy": {
 				Path:        "/seattle/officer/search",
-				QueryParams: []string{"first_name", "last_name"},
+				QueryParams: []string{"q", "first_name", "middle_name", "last_name", "full_name", "nicknames", "mode", "min_score", "threshold", "sort", "rank"},
+			},
+			"autocomplete": {
+				Path:        "/seattle/officer/autocomplete",
+				QueryParams: []string{"prefix", "limit"},
 			},
 			"historical-exact": {
 				Path:        "/seattle/officer/historical",
-				QueryParams: []string{"badge"},
+				QueryParams: []string{"badge", "rank"},
 			},
 		},
 	}
 }
 
-// SeattleGetOfficerByBadge returns an officer by their badge. It searches the full historical
-// roster list but only returns the most recent entry.
-func (c *Client) SeattleGetOfficerByBadge(badge string) ([]*SeattleOfficer, error) {
+// SeattleGetOfficerByBadge returns the officers matching a badge search. It searches the full
+// historical roster list but only returns the most recent entry of each badge, ranked by how
+// close the badge is to the badge searched.
+func (c *Client) SeattleGetOfficerByBadge(badge BadgeSearch) ([]*SeattleOfficer, error) {
+	condition, distance, args := badge.match("o.badge_normalized")
 	rows, err := c.pool.Query(context.Background(),
-		`
-			WITH max_roster AS (SELECT MAX(date) max_date FROM seattle_officers)
+		fmt.Sprintf(`
+			WITH max_roster AS (SELECT MAX(date) max_date FROM seattle_officers),
+			o AS (
+				SELECT DISTINCT ON (o.badge) *
+				FROM seattle_officers o
+				WHERE %s
+				ORDER BY
+					o.badge,
+					o.date DESC
+			)
 			SELECT
 				o.date,
 				o.badge,
@@ -132,13 +269,13 @@ func (c *Client) SeattleGetOfficerByBadge(badge string) ([]*SeattleOfficer, erro
 				o.unit,
 				o.unit_description,
 				CASE WHEN o.date = m.max_date THEN TRUE ELSE FALSE END is_current
-			FROM seattle_officers o
+			FROM o
 			CROSS JOIN max_roster m
-			WHERE o.badge = $1
-			ORDER BY o.date DESC
-			LIMIT 1;
-		`,
-		badge,
+			ORDER BY
+				%s,
+				o.badge;
+		`, condition, distance),
+		args...,
 	)
 	if err != nil {
 		return nil, err
@@ -167,7 +304,7 @@ func (c *Client) SeattleGetOfficerByBadgeHistorical(badge string) ([]*SeattleOff
 				CASE WHEN o.date = m.max_date THEN TRUE ELSE FALSE END is_current
 			FROM seattle_officers o
 			CROSS JOIN max_roster m
-			WHERE o.badge = $1
+			WHERE o.badge_normalized = normalize_badge($1)
 			ORDER BY o.date DESC;
 		`,
 		badge,
@@ -182,16 +319,17 @@ func (c *Client) SeattleGetOfficerByBadgeHistorical(badge string) ([]*SeattleOff
 
 // SeattleSearchOfficerByName returns an officer by their first or last name. It searches the full historical
 // roster list but only returns the most recent entry.
-func (c *Client) SeattleSearchOfficerByName(firstName, lastName string) ([]*SeattleOfficer, error) {
+func (c *Client) SeattleSearchOfficerByName(firstName, lastName string, nicknames []string, filters Filters) ([]*SeattleOfficer, error) {
+	conditions, args := filters.conditions(seattleFilterColumns, []interface{}{firstName, lastName, nicknames})
 	rows, err := c.pool.Query(context.Background(),
-		`
+		fmt.Sprintf(`
 			WITH o AS (
 				SELECT
 					*,
 					row_number() over (partition by o.badge order by o.date desc) seqnum
 				FROM seattle_officers o
-				WHERE LOWER(o.first_name) LIKE LOWER($1)
-				AND LOWER(o.last_name) LIKE LOWER($2)
+				WHERE (%s OR o.first_name_normalized = ANY($3))
+				AND %s
 			),
 			max_roster AS (SELECT MAX(date) max_date FROM seattle_officers)
 			SELECT
@@ -206,34 +344,60 @@ func (c *Client) SeattleSearchOfficerByName(firstName, lastName string) ([]*Seat
 				o.unit_description,
 				CASE WHEN o.date = max_roster.max_date THEN TRUE ELSE FALSE END is_current
 			FROM o, max_roster
-			WHERE o.seqnum = 1
+			WHERE o.seqnum = 1%s
 			ORDER BY 
 				o.date DESC,
 				o.full_name;
-		`,
-		firstName,
-		lastName,
+		`, filters.matchName("o.first_name", 1), filters.matchName("o.last_name", 2), conditions),
+		args...,
 	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	return seattleMarshalOfficerRows(rows)
+	return seattleMarshalOfficerRows(rows, nicknames...)
 }
 
 // SeattleFuzzySearchByName returns an list of officers by their full name using fuzzy matching.
 // It searches the full historical roster list but only returns the last availabe roster entry. Entries
-// are sorted by date in descending order.
-func (c *Client) SeattleFuzzySearchByName(name string) ([]*SeattleOfficer, error) {
+// are sorted by match score, or by date first when sorting by recency.
+func (c *Client) SeattleFuzzySearchByName(name string, opts FuzzyOptions) ([]*SeattleOfficer, error) {
+	return c.seattleFuzzySearch(fuzzyFullName, name, opts)
+}
+
+// SeattleFuzzySearchByFullName returns an list of officers by their full name, middle name included,
+// using fuzzy matching.
+func (c *Client) SeattleFuzzySearchByFullName(fullName string, opts FuzzyOptions) ([]*SeattleOfficer, error) {
+	return c.seattleFuzzySearch(seattleFuzzyFullName, fullName, opts)
+}
+
+// SeattleFuzzySearchByFirstName returns an list of officers by their first name using fuzzy matching.
+// It searches the full historical roster list but only returns the last availabe roster entry. Entries
+// are sorted by match score, or by date first when sorting by recency.
+func (c *Client) SeattleFuzzySearchByFirstName(firstName string, opts FuzzyOptions) ([]*SeattleOfficer, error) {
+	return c.seattleFuzzySearch(fuzzyFirstName, firstName, opts)
+}
+
+// SeattleFuzzySearchByLastName returns an list of officers by their last name using fuzzy matching.
+// It searches the full historical roster list but only returns the last availabe roster entry. Entries
+// are sorted by match score, or by date first when sorting by recency.
+func (c *Client) SeattleFuzzySearchByLastName(lastName string, opts FuzzyOptions) ([]*SeattleOfficer, error) {
+	return c.seattleFuzzySearch(fuzzyLastName, lastName, opts)
+}
+
+// seattleFuzzySearch returns the last available roster entry of the officers whose name matches
+// value, using the matching requested through opts.
+func (c *Client) seattleFuzzySearch(name fuzzyName, value string, opts FuzzyOptions) ([]*SeattleOfficer, error) {
+	match := name.match(value, opts).withMiddleName("o.middle_name", opts.MiddleName)
 	rows, err := c.pool.Query(context.Background(),
-		`
+		fmt.Sprintf(`
 			WITH o AS (
 				SELECT
 					*,
 					row_number() over (partition by o.badge order by o.date desc) seqnum
 				FROM seattle_officers o
-				WHERE LOWER(first_name || ' ' || last_name) % LOWER($1)
+				WHERE %[1]s
 			),
 			max_roster AS (SELECT MAX(date) max_date FROM seattle_officers)
 			SELECT
@@ -246,108 +410,183 @@ func (c *Client) SeattleFuzzySearchByName(name string) ([]*SeattleOfficer, error
 				o.title,
 				o.unit,
 				o.unit_description,
-				CASE WHEN o.date = max_roster.max_date THEN TRUE ELSE FALSE END is_current
+				CASE WHEN o.date = max_roster.max_date THEN TRUE ELSE FALSE END is_current,
+				%[2]s match_score,
+				%[3]s matched_on
 			FROM o, max_roster
 			WHERE o.seqnum = 1
-			ORDER BY
-				o.date DESC,
-				SIMILARITY(LOWER(o.first_name || ' ' || o.last_name), LOWER($1)) DESC;
-		`,
-		name,
+			ORDER BY %[4]s;
+		`, match.condition, match.score, match.matchedOn, opts.orderBy("o.date")),
+		match.args...,
 	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	return seattleMarshalOfficerRows(rows)
+	fuzzy := &fuzzyRows{Rows: rows}
+	officers, err := seattleMarshalOfficerRows(fuzzy, opts.Nicknames...)
+	if err != nil {
+		return nil, err
+	}
+	for i, ofc := range officers {
+		ofc.MatchScore, ofc.MatchedOn = fuzzy.matches[i].MatchScore, fuzzy.matches[i].MatchedOn
+	}
+	return officers, nil
 }
 
-// SeattleFuzzySearchByFirstName returns an list of officers by their first name using fuzzy matching.
-// It searches the full historical roster list but only returns the last availabe roster entry. Entries
-// are sorted by date in descending order.
-func (c *Client) SeattleFuzzySearchByFirstName(firstName string) ([]*SeattleOfficer, error) {
+// SeattlePrecincts returns the SPD precinct boundaries along with the units mapped to
+// each precinct and the number of officers assigned to them on the current roster.
+func (c *Client) SeattlePrecincts() (*SeattlePrecinctCollection, error) {
 	rows, err := c.pool.Query(context.Background(),
 		`
 			WITH o AS (
-				SELECT
-					*,
-					row_number() over (partition by o.badge order by o.date desc) seqnum
-				FROM seattle_officers o
-				WHERE LOWER(first_name) % LOWER($1)
-			),
-			max_roster AS (SELECT MAX(date) max_date FROM seattle_officers)
+				SELECT *
+				FROM seattle_officers
+				WHERE date = (SELECT MAX(date) FROM seattle_officers)
+			)
 			SELECT
-				o.date,
-				o.badge,
-				o.full_name,
-				o.first_name,
-				o.middle_name,
-				o.last_name,
-				o.title,
-				o.unit,
-				o.unit_description,
-				CASE WHEN o.date = max_roster.max_date THEN TRUE ELSE FALSE END is_current
-			FROM o, max_roster
-			WHERE o.seqnum = 1
-			ORDER BY
-				o.date DESC,
-				SIMILARITY(LOWER(o.first_name), LOWER($1)) DESC;
+				p.name,
+				p.geometry,
+				COUNT(o.id) headcount,
+				ARRAY_REMOVE(ARRAY_AGG(DISTINCT o.unit ORDER BY o.unit), NULL) units
+			FROM seattle_precincts p
+			LEFT JOIN o ON o.unit_description ILIKE p.unit_description_pattern
+			GROUP BY
+				p.id,
+				p.name,
+				p.geometry
+			ORDER BY p.id;
 		`,
-		firstName,
 	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	return seattleMarshalOfficerRows(rows)
+	precincts := &SeattlePrecinctCollection{
+		Type:     "FeatureCollection",
+		Features: []*SeattlePrecinctFeature{},
+	}
+	for rows.Next() {
+		properties := SeattlePrecinctProperties{}
+		var geometry []byte
+		err := rows.Scan(
+			&properties.Name,
+			&geometry,
+			&properties.Headcount,
+			&properties.Units,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		precincts.Features = append(precincts.Features, &SeattlePrecinctFeature{
+			Type:       "Feature",
+			Geometry:   geometry,
+			Properties: &properties,
+		})
+	}
+	return precincts, nil
 }
 
-// SeattleFuzzySearchByLastName returns an list of officers by their last name using fuzzy matching.
-// It searches the full historical roster list but only returns the last availabe roster entry. Entries
-// are sorted by date in descending order.
-func (c *Client) SeattleFuzzySearchByLastName(lastName string) ([]*SeattleOfficer, error) {
+// SeattleHeadcountByDate returns the number of officers on each available roster date,
+// optionally grouped by title or unit.
+func (c *Client) SeattleHeadcountByDate(groupBy string) ([]*HeadcountStat, error) {
+	return c.headcountByDate("seattle_officers", groupBy)
+}
+
+// SeattleTitleChanges returns the officers whose rank changed between consecutive rosters,
+// where the roster carrying the new title is dated within the from and to dates inclusive.
+// Entries are sorted by date in descending order.
+func (c *Client) SeattleTitleChanges(from, to time.Time) ([]*SeattleTitleChange, error) {
 	rows, err := c.pool.Query(context.Background(),
 		`
 			WITH o AS (
 				SELECT
 					*,
-					row_number() over (partition by o.badge order by o.date desc) seqnum
+					LAG(o.date) over (partition by o.badge order by o.date) previous_date,
+					LAG(o.title) over (partition by o.badge order by o.date) previous_title
 				FROM seattle_officers o
-				WHERE LOWER(last_name) % LOWER($1)
-			),
-			max_roster AS (SELECT MAX(date) max_date FROM seattle_officers)
+			)
 			SELECT
 				o.date,
+				o.previous_date,
 				o.badge,
 				o.full_name,
 				o.first_name,
 				o.middle_name,
 				o.last_name,
+				o.previous_title,
 				o.title,
 				o.unit,
-				o.unit_description,
-				CASE WHEN o.date = max_roster.max_date THEN TRUE ELSE FALSE END is_current
-			FROM o, max_roster
-			WHERE o.seqnum = 1
+				o.unit_description
+			FROM o
+			WHERE o.previous_title IS NOT NULL
+			AND LOWER(o.previous_title) <> LOWER(o.title)
+			AND o.date BETWEEN $1 AND $2
 			ORDER BY
 				o.date DESC,
-				SIMILARITY(LOWER(o.last_name), LOWER($1)) DESC;
+				o.last_name,
+				o.first_name;
 		`,
-		lastName,
+		from,
+		to,
 	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	return seattleMarshalOfficerRows(rows)
+	changes := []*SeattleTitleChange{}
+	for rows.Next() {
+		var date, previousDate time.Time
+		var badge, fullName, firstName, middleName, lastName, previousTitle, title, unit, unitDescription nulls.String
+		err := rows.Scan(
+			&date,
+			&previousDate,
+			&badge,
+			&fullName,
+			&firstName,
+			&middleName,
+			&lastName,
+			&previousTitle,
+			&title,
+			&unit,
+			&unitDescription,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		change := seattleClassifyTitleChange(previousTitle.String, title.String)
+		if change == "" {
+			continue
+		}
+
+		changes = append(changes, &SeattleTitleChange{
+			Date:            date.Format("2006-01-02"),
+			PreviousDate:    previousDate.Format("2006-01-02"),
+			Badge:           badge.String,
+			FullName:        fullName.String,
+			FirstName:       firstName.String,
+			MiddleName:      middleName.String,
+			LastName:        lastName.String,
+			PreviousTitle:   previousTitle.String,
+			Title:           title.String,
+			Unit:            unit.String,
+			UnitDescription: unitDescription.String,
+			Change:          change,
+		})
+	}
+	return changes, nil
 }
 
 // seattleMarshalOfficerRows takes SQL return objects and marshals them onto the
 // SeattleOfficer object for return as JSON by the API.
-func seattleMarshalOfficerRows(rows pgx.Rows) ([]*SeattleOfficer, error) {
+func seattleMarshalOfficerRows(rows pgx.Rows, nicknames ...string) ([]*SeattleOfficer, error) {
 	officers := []*SeattleOfficer{}
 	for rows.Next() {
 		ofc := seattleOfficer{}
@@ -371,17 +610,26 @@ func seattleMarshalOfficerRows(rows pgx.Rows) ([]*SeattleOfficer, error) {
 		returnOfficer := SeattleOfficer{
 			ofc.Date.Format("2006-01-02"),
 			ofc.Badge.String,
+			NormalizeBadge(ofc.Badge.String),
 			ofc.FullName.String,
 			ofc.Title.String,
+			CanonicalRank(ofc.Title.String),
 			ofc.Unit.String,
 			ofc.UnitDescription.String,
 			ofc.FirstName.String,
 			ofc.MiddleName.String,
 			ofc.LastName.String,
 			ofc.Current,
+			NameMatch{MatchedNickname: matchedNickname(ofc.FirstName.String, nicknames)},
 		}
 
 		officers = append(officers, &returnOfficer)
 	}
 	return officers, nil
 }
+
+// SeattleAutocomplete suggests up to limit officer names starting with prefix, along with each
+// officer's badge and title.
+func (c *Client) SeattleAutocomplete(prefix string, limit int) ([]*OfficerSuggestion, error) {
+	return c.autocomplete(namedAutocompleteSource("seattle_officers", "o.badge", "o.title", "o.date"), prefix, limit)
+}
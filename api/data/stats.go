@@ -0,0 +1,75 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// HeadcountStat is the number of officers on a single roster date, optionally
+// broken down by a grouping column such as title or unit.
+type HeadcountStat struct {
+	Date  string `json:"date"`
+	Group string `json:"group,omitempty"`
+	Count int    `json:"count"`
+}
+
+// headcountGroupColumns maps the supported group_by values onto the columns they group by
+var headcountGroupColumns = map[string]string{
+	"title": "o.title",
+	"unit":  "o.unit",
+}
+
+// headcountByDate counts the officers on each roster date stored in table. If groupBy
+// is provided the counts are further broken down by that column.
+func (c *Client) headcountByDate(table, groupBy string) ([]*HeadcountStat, error) {
+	group := "''"
+	if groupBy != "" {
+		column, ok := headcountGroupColumns[groupBy]
+		if !ok {
+			return nil, fmt.Errorf("unsupported group_by: %s", groupBy)
+		}
+		group = fmt.Sprintf("COALESCE(%s, '')", column)
+	}
+
+	rows, err := c.pool.Query(context.Background(),
+		fmt.Sprintf(`
+			SELECT
+				o.date,
+				%[1]s grp,
+				COUNT(*) headcount
+			FROM %[2]s o
+			WHERE o.date IS NOT NULL
+			GROUP BY
+				o.date,
+				%[1]s
+			ORDER BY
+				o.date,
+				headcount DESC,
+				grp;
+		`, group, table),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats := []*HeadcountStat{}
+	for rows.Next() {
+		var date time.Time
+		stat := HeadcountStat{}
+		err := rows.Scan(
+			&date,
+			&stat.Group,
+			&stat.Count,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		stat.Date = date.Format("2006-01-02")
+		stats = append(stats, &stat)
+	}
+	return stats, nil
+}
@@ -2,6 +2,7 @@ package data
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/gobuffalo/nulls"
@@ -10,23 +11,40 @@ import (
 
 // TacomaOfficer is the object model for Tacoma PD officers
 type TacomaOfficer struct {
-	Date       string `json:"date,omitempty"`
-	FirstName  string `json:"first_name,omitempty"`
-	LastName   string `json:"last_name,omitempty"`
-	Title      string `json:"title,omitempty"`
-	Department string `json:"department,omitempty"`
-	Salary     string `json:"salary,omitempty"`
+	Date          string   `json:"date,omitempty"`
+	FirstName     string   `json:"first_name,omitempty"`
+	LastName      string   `json:"last_name,omitempty"`
+	Title         string   `json:"title,omitempty"`
+	CanonicalRank string   `json:"canonical_rank,omitempty"`
+	Department    string   `json:"department,omitempty"`
+	Salary        string   `json:"salary,omitempty"`
+	SalaryAmount  *float64 `json:"salary_amount,omitempty"`
+	NameMatch
 }
 
 // tacomaOfficer is an internal intermediary between the returned SQL rows data
 // and the actual JSON return itself.
 type tacomaOfficer struct {
-	Date       time.Time
-	FirstName  nulls.String
-	LastName   nulls.String
-	Title      nulls.String
-	Department nulls.String
-	Salary     nulls.String
+	Date         time.Time
+	FirstName    nulls.String
+	LastName     nulls.String
+	Title        nulls.String
+	Department   nulls.String
+	Salary       nulls.String
+	SalaryAmount nulls.Float64
+}
+
+// tacomaFilterColumns maps the filters supported by Tacoma strict searches onto their columns
+var tacomaFilterColumns = map[string]string{
+	"title":  "o.title",
+	"salary": "o.salary_amount",
+}
+
+// tacomaSalaryGroupColumns maps the group_by values supported by Tacoma salary statistics onto
+// the columns they group by
+var tacomaSalaryGroupColumns = map[string]string{
+	"title":      "o.title",
+	"department": "o.department",
 }
 
 // TacomaOfficerMetadata retrieves metadata describing the TacomaOfficer struct
@@ -45,6 +63,10 @@ func (c *Client) TacomaOfficerMetadata() *DepartmentMetadata {
 				"FieldName": "title",
 				"Label":     "Title",
 			},
+			{
+				"FieldName": "canonical_rank",
+				"Label":     "Canonical Rank",
+			},
 			{
 				"FieldName": "department",
 				"Label":     "Department",
@@ -53,6 +75,10 @@ func (c *Client) TacomaOfficerMetadata() *DepartmentMetadata {
 				"FieldName": "salary",
 				"Label":     "Salary 2019",
 			},
+			{
+				"FieldName": "salary_amount",
+				"Label":     "Salary 2019 (Amount)",
+			},
 		},
 		LastAvailableRosterDate: "2019",
 		Name:                    "Tacoma PD",
@@ -60,54 +86,64 @@ func (c *Client) TacomaOfficerMetadata() *DepartmentMetadata {
 		SearchRoutes: map[string]*SearchRouteMetadata{
 			"exact": {
 				Path:        "/tacoma/officer",
-				QueryParams: []string{"first_name", "last_name"},
+				QueryParams: []string{"q", "first_name", "last_name", "nicknames", "title", "salary_min", "salary_max", "mode", "rank"},
 			},
 			"fuzzy": {
 				Path:        "/tacoma/officer/search",
-				QueryParams: []string{"first_name", "last_name"},
+				QueryParams: []string{"q", "first_name", "last_name", "nicknames", "mode", "min_score", "threshold", "sort", "rank"},
+			},
+			"autocomplete": {
+				Path:        "/tacoma/officer/autocomplete",
+				QueryParams: []string{"prefix", "limit"},
 			},
 		},
 	}
 }
 
-// TacomaSearchOfficerByName invokes tacoma_search_officer_by_name_p
-func (c *Client) TacomaSearchOfficerByName(firstName, lastName string) ([]*TacomaOfficer, error) {
+// TacomaSearchOfficerByName returns an officer by their first or last name.
+func (c *Client) TacomaSearchOfficerByName(firstName, lastName string, nicknames []string, filters Filters) ([]*TacomaOfficer, error) {
+	conditions, args := filters.conditions(tacomaFilterColumns, []interface{}{firstName, lastName, nicknames})
 	rows, err := c.pool.Query(context.Background(),
-		`
+		fmt.Sprintf(`
 			SELECT
 				date,
 				first_name,
 				last_name,
 				title,
 				department,
-				salary
-			FROM tacoma_search_officer_by_name_p(first_name := $1, last_name := $2);
-		`,
-		firstName,
-		lastName,
+				salary,
+				salary_amount
+			FROM tacoma_officers o
+			WHERE (%s OR o.first_name_normalized = ANY($3)) AND %s%s;
+		`, filters.matchName("o.first_name", 1), filters.matchName("o.last_name", 2), conditions),
+		args...,
 	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	return marshalTacomaOfficerRows(rows)
+	return marshalTacomaOfficerRows(rows, nicknames...)
 }
 
-// TacomaFuzzySearchByName invokes tacoma_fuzzy_search_officer_by_name_p
-func (c *Client) TacomaFuzzySearchByName(name string) ([]*TacomaOfficer, error) {
+// TacomaSearchOfficersByFilters returns the officers matching the range filters of filters,
+// such as a salary range.
+func (c *Client) TacomaSearchOfficersByFilters(filters Filters) ([]*TacomaOfficer, error) {
+	conditions, args := filters.conditions(tacomaFilterColumns, []interface{}{})
 	rows, err := c.pool.Query(context.Background(),
-		`
+		fmt.Sprintf(`
 			SELECT
-				date,
-				first_name,
-				last_name,
-				title,
-				department,
-				salary
-			FROM tacoma_fuzzy_search_officer_by_name_p(full_name := $1);
-		`,
-		name,
+				o.date,
+				o.first_name,
+				o.last_name,
+				o.title,
+				o.department,
+				o.salary,
+				o.salary_amount
+			FROM tacoma_officers o
+			WHERE TRUE%s;
+		`, conditions),
+		args...,
 	)
 	if err != nil {
 		return nil, err
@@ -117,53 +153,72 @@ func (c *Client) TacomaFuzzySearchByName(name string) ([]*TacomaOfficer, error)
 	return marshalTacomaOfficerRows(rows)
 }
 
-// TacomaFuzzySearchByFirstName invokes tacoma_fuzzy_search_officer_by_first_name_p
-func (c *Client) TacomaFuzzySearchByFirstName(firstName string) ([]*TacomaOfficer, error) {
+// TacomaFuzzySearchByName returns an list of officers by their full name using fuzzy matching.
+// Entries are sorted by how well they match the full name in descending order,
+// or by roster date first when sorting by recency.
+func (c *Client) TacomaFuzzySearchByName(name string, opts FuzzyOptions) ([]*TacomaOfficer, error) {
+	return c.tacomaFuzzySearch(fuzzyFullName, name, opts)
+}
+
+// TacomaFuzzySearchByFirstName returns an list of officers by their first name using fuzzy matching.
+// Entries are sorted by how well they match the first name in descending order,
+// or by roster date first when sorting by recency.
+func (c *Client) TacomaFuzzySearchByFirstName(firstName string, opts FuzzyOptions) ([]*TacomaOfficer, error) {
+	return c.tacomaFuzzySearch(fuzzyFirstName, firstName, opts)
+}
+
+// TacomaFuzzySearchByLastName returns an list of officers by their last name using fuzzy matching.
+// Entries are sorted by how well they match the last name in descending order,
+// or by roster date first when sorting by recency.
+func (c *Client) TacomaFuzzySearchByLastName(lastName string, opts FuzzyOptions) ([]*TacomaOfficer, error) {
+	return c.tacomaFuzzySearch(fuzzyLastName, lastName, opts)
+}
+
+// tacomaFuzzySearch returns the officers whose name matches value, using the matching
+// requested through opts.
+func (c *Client) tacomaFuzzySearch(name fuzzyName, value string, opts FuzzyOptions) ([]*TacomaOfficer, error) {
+	match := name.match(value, opts)
 	rows, err := c.pool.Query(context.Background(),
-		`
+		fmt.Sprintf(`
 			SELECT
-				date,
-				first_name,
-				last_name,
-				title,
-				department,
-				salary
-			FROM tacoma_fuzzy_search_officer_by_first_name_p(first_name := $1);
-		`,
-		firstName,
+				o.date,
+				o.first_name,
+				o.last_name,
+				o.title,
+				o.department,
+				o.salary,
+				o.salary_amount,
+				%[2]s match_score,
+				%[3]s matched_on
+			FROM tacoma_officers o
+			WHERE %[1]s
+			ORDER BY %[4]s;
+		`, match.condition, match.score, match.matchedOn, opts.orderBy("o.date")),
+		match.args...,
 	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	return marshalTacomaOfficerRows(rows)
-}
-
-// TacomaFuzzySearchByLastName invokes tacoma_fuzzy_search_officer_by_last_name_p
-func (c *Client) TacomaFuzzySearchByLastName(lastName string) ([]*TacomaOfficer, error) {
-	rows, err := c.pool.Query(context.Background(),
-		`
-			SELECT
-				date,
-				first_name,
-				last_name,
-				title,
-				department,
-				salary
-			FROM tacoma_fuzzy_search_officer_by_last_name_p(last_name := $1);
-		`,
-		lastName,
-	)
+	fuzzy := &fuzzyRows{Rows: rows}
+	officers, err := marshalTacomaOfficerRows(fuzzy, opts.Nicknames...)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
+	for i, ofc := range officers {
+		ofc.MatchScore, ofc.MatchedOn = fuzzy.matches[i].MatchScore, fuzzy.matches[i].MatchedOn
+	}
+	return officers, nil
+}
 
-	return marshalTacomaOfficerRows(rows)
+// TacomaHeadcountByDate returns the number of officers on each available roster date,
+// optionally grouped by title.
+func (c *Client) TacomaHeadcountByDate(groupBy string) ([]*HeadcountStat, error) {
+	return c.headcountByDate("tacoma_officers", groupBy)
 }
 
-func marshalTacomaOfficerRows(rows pgx.Rows) ([]*TacomaOfficer, error) {
+func marshalTacomaOfficerRows(rows pgx.Rows, nicknames ...string) ([]*TacomaOfficer, error) {
 	officers := []*TacomaOfficer{}
 	for rows.Next() {
 		ofc := tacomaOfficer{}
@@ -174,6 +229,7 @@ func marshalTacomaOfficerRows(rows pgx.Rows) ([]*TacomaOfficer, error) {
 			&ofc.Title,
 			&ofc.Department,
 			&ofc.Salary,
+			&ofc.SalaryAmount,
 		)
 
 		if err != nil {
@@ -185,10 +241,25 @@ func marshalTacomaOfficerRows(rows pgx.Rows) ([]*TacomaOfficer, error) {
 			ofc.FirstName.String,
 			ofc.LastName.String,
 			ofc.Title.String,
+			CanonicalRank(ofc.Title.String),
 			ofc.Department.String,
 			ofc.Salary.String,
+			nullFloat(ofc.SalaryAmount),
+			NameMatch{MatchedNickname: matchedNickname(ofc.FirstName.String, nicknames)},
 		}
 		officers = append(officers, &returnOfficer)
 	}
 	return officers, nil
 }
+
+// TacomaSalaryStats summarizes the 2019 salaries of officers, optionally grouped by title or
+// department.
+func (c *Client) TacomaSalaryStats(groupBy string) ([]*SalaryStat, error) {
+	return c.salaryStats("tacoma_officers", "o.salary_amount", tacomaSalaryGroupColumns, groupBy)
+}
+
+// TacomaAutocomplete suggests up to limit officer names starting with prefix, along with each
+// officer's badge and title.
+func (c *Client) TacomaAutocomplete(prefix string, limit int) ([]*OfficerSuggestion, error) {
+	return c.autocomplete(namedAutocompleteSource("tacoma_officers", "", "o.title", "o.date"), prefix, limit)
+}
@@ -2,6 +2,7 @@ package data
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/gobuffalo/nulls"
 	"github.com/jackc/pgx/v4"
@@ -9,10 +10,12 @@ import (
 
 // ThurstonCountyOfficer is the object model for BPD officers
 type ThurstonCountyOfficer struct {
-	LastName  string `json:"last_name,omitempty"`
-	FirstName string `json:"first_name,omitempty"`
-	Title     string `json:"title,omitempty"`
-	CallSign  string `json:"call_sign,omitempty"`
+	LastName      string `json:"last_name,omitempty"`
+	FirstName     string `json:"first_name,omitempty"`
+	Title         string `json:"title,omitempty"`
+	CanonicalRank string `json:"canonical_rank,omitempty"`
+	CallSign      string `json:"call_sign,omitempty"`
+	NameMatch
 }
 
 // thurstonCountyOfficer is an internal intermediary between the returned SQL rows data
@@ -24,6 +27,12 @@ type thurstonCountyOfficer struct {
 	CallSign  nulls.String
 }
 
+// thurstonCountyFilterColumns maps the filters supported by ThurstonCounty strict searches onto their columns
+var thurstonCountyFilterColumns = map[string]string{
+	"title":     "o.title",
+	"call_sign": "o.call_sign",
+}
+
 // ThurstonCountyOfficerMetadata retrieves metadata describing the ThurstonCountyOfficer struct
 func (c *Client) ThurstonCountyOfficerMetadata() *DepartmentMetadata {
 	return &DepartmentMetadata{
@@ -40,6 +49,10 @@ func (c *Client) ThurstonCountyOfficerMetadata() *DepartmentMetadata {
 				"FieldName": "title",
 				"Label":     "Officer Title",
 			},
+			{
+				"FieldName": "canonical_rank",
+				"Label":     "Canonical Rank",
+			},
 			{
 				"FieldName": "call_sign",
 				"Label":     "Call Sign",
@@ -51,123 +64,102 @@ func (c *Client) ThurstonCountyOfficerMetadata() *DepartmentMetadata {
 		SearchRoutes: map[string]*SearchRouteMetadata{
 			"exact": {
 				Path:        "/thurston_county/officer",
-				QueryParams: []string{"first_name", "last_name"},
+				QueryParams: []string{"q", "first_name", "last_name", "nicknames", "title", "call_sign", "mode", "rank"},
 			},
 			"fuzzy": {
 				Path:        "/thurston_county/officer/search",
-				QueryParams: []string{"first_name", "last_name"},
+				QueryParams: []string{"q", "first_name", "last_name", "nicknames", "mode", "min_score", "threshold", "rank"},
+			},
+			"autocomplete": {
+				Path:        "/thurston_county/officer/autocomplete",
+				QueryParams: []string{"prefix", "limit"},
 			},
 		},
 	}
 }
 
 // ThurstonCountySearchOfficerByName returns an officer by their first or last name.
-func (c *Client) ThurstonCountySearchOfficerByName(firstName, lastName string) ([]*ThurstonCountyOfficer, error) {
+func (c *Client) ThurstonCountySearchOfficerByName(firstName, lastName string, nicknames []string, filters Filters) ([]*ThurstonCountyOfficer, error) {
+	conditions, args := filters.conditions(thurstonCountyFilterColumns, []interface{}{firstName, lastName, nicknames})
 	rows, err := c.pool.Query(context.Background(),
-		`
+		fmt.Sprintf(`
 			SELECT
 				o.last_name,
 				o.first_name,
 				o.title,
                 o.call_sign
 			FROM thurston_officers o
-			WHERE LOWER(o.first_name) LIKE LOWER($1) AND LOWER(o.last_name) LIKE LOWER($2)
+			WHERE (%s OR o.first_name_normalized = ANY($3)) AND %s%s
 			ORDER BY 
 				o.last_name,
 				o.first_name;
-		`,
-		firstName,
-		lastName,
+		`, filters.matchName("o.first_name", 1), filters.matchName("o.last_name", 2), conditions),
+		args...,
 	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	return thurstonCountyMarshalOfficerRows(rows)
+	return thurstonCountyMarshalOfficerRows(rows, nicknames...)
 }
 
 // ThurstonCountyFuzzySearchByName returns an list of officers by their full name using fuzzy matching.
-// Entries are sorted by similarity to the full name in descending order.
-func (c *Client) ThurstonCountyFuzzySearchByName(name string) ([]*ThurstonCountyOfficer, error) {
-	rows, err := c.pool.Query(context.Background(),
-		`
-			SELECT
-				o.last_name,
-				o.first_name,
-				o.title,
-                o.call_sign
-
-			FROM thurston_officers o
-			WHERE LOWER(first_name || ' ' || last_name) % LOWER($1)
-			ORDER BY
-				SIMILARITY(LOWER(o.first_name || ' ' || o.last_name), LOWER($1)) DESC;
-		`,
-		name,
-	)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	return thurstonCountyMarshalOfficerRows(rows)
+// Entries are sorted by how well they match the full name in descending order.
+func (c *Client) ThurstonCountyFuzzySearchByName(name string, opts FuzzyOptions) ([]*ThurstonCountyOfficer, error) {
+	return c.thurstonCountyFuzzySearch(fuzzyFullName, name, opts)
 }
 
 // ThurstonCountyFuzzySearchByFirstName returns an list of officers by their first name using fuzzy matching.
-// Entries are sorted by similarity to the full name in descending order.
-func (c *Client) ThurstonCountyFuzzySearchByFirstName(firstName string) ([]*ThurstonCountyOfficer, error) {
-	rows, err := c.pool.Query(context.Background(),
-		`
-			SELECT
-				o.last_name,
-				o.first_name,
-				o.title,
-                o.call_sign
-
-				FROM thurston_officers o
-			WHERE LOWER(first_name) % LOWER($1)
-			ORDER BY
-				SIMILARITY(LOWER(o.first_name), LOWER($1)) DESC;
-		`,
-		firstName,
-	)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	return thurstonCountyMarshalOfficerRows(rows)
+// Entries are sorted by how well they match the first name in descending order.
+func (c *Client) ThurstonCountyFuzzySearchByFirstName(firstName string, opts FuzzyOptions) ([]*ThurstonCountyOfficer, error) {
+	return c.thurstonCountyFuzzySearch(fuzzyFirstName, firstName, opts)
 }
 
 // ThurstonCountyFuzzySearchByLastName returns an list of officers by their last name using fuzzy matching.
-// Entries are sorted by similarity to the full name in descending order.
-func (c *Client) ThurstonCountyFuzzySearchByLastName(lastName string) ([]*ThurstonCountyOfficer, error) {
+// Entries are sorted by how well they match the last name in descending order.
+func (c *Client) ThurstonCountyFuzzySearchByLastName(lastName string, opts FuzzyOptions) ([]*ThurstonCountyOfficer, error) {
+	return c.thurstonCountyFuzzySearch(fuzzyLastName, lastName, opts)
+}
+
+// thurstonCountyFuzzySearch returns the officers whose name matches value, using the matching
+// requested through opts.
+func (c *Client) thurstonCountyFuzzySearch(name fuzzyName, value string, opts FuzzyOptions) ([]*ThurstonCountyOfficer, error) {
+	match := name.match(value, opts)
 	rows, err := c.pool.Query(context.Background(),
-		`
+		fmt.Sprintf(`
 			SELECT
 				o.last_name,
 				o.first_name,
 				o.title,
-                o.call_sign
-
+				o.call_sign,
+				%[2]s match_score,
+				%[3]s matched_on
 			FROM thurston_officers o
-			WHERE LOWER(last_name) % LOWER($1)
-			ORDER BY
-				SIMILARITY(LOWER(o.last_name), LOWER($1)) DESC;
-		`,
-		lastName,
+			WHERE %[1]s
+			ORDER BY match_score DESC;
+		`, match.condition, match.score, match.matchedOn),
+		match.args...,
 	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	return thurstonCountyMarshalOfficerRows(rows)
+	fuzzy := &fuzzyRows{Rows: rows}
+	officers, err := thurstonCountyMarshalOfficerRows(fuzzy, opts.Nicknames...)
+	if err != nil {
+		return nil, err
+	}
+	for i, ofc := range officers {
+		ofc.MatchScore, ofc.MatchedOn = fuzzy.matches[i].MatchScore, fuzzy.matches[i].MatchedOn
+	}
+	return officers, nil
 }
 
 // thurstonCountyMarshalOfficerRows takes SQL return objects and marshals them onto the
 // ThurstonCountyOfficer object for return as JSON by the API.
-func thurstonCountyMarshalOfficerRows(rows pgx.Rows) ([]*ThurstonCountyOfficer, error) {
+func thurstonCountyMarshalOfficerRows(rows pgx.Rows, nicknames ...string) ([]*ThurstonCountyOfficer, error) {
 	officers := []*ThurstonCountyOfficer{}
 	for rows.Next() {
 		ofc := thurstonCountyOfficer{}
@@ -186,10 +178,18 @@ func thurstonCountyMarshalOfficerRows(rows pgx.Rows) ([]*ThurstonCountyOfficer,
 			ofc.LastName.String,
 			ofc.FirstName.String,
 			ofc.Title.String,
+			CanonicalRank(ofc.Title.String),
 			ofc.CallSign.String,
+			NameMatch{MatchedNickname: matchedNickname(ofc.FirstName.String, nicknames)},
 		}
 
 		officers = append(officers, &returnOfficer)
 	}
 	return officers, nil
 }
+
+// ThurstonCountyAutocomplete suggests up to limit officer names starting with prefix, along with each
+// officer's badge and title.
+func (c *Client) ThurstonCountyAutocomplete(prefix string, limit int) ([]*OfficerSuggestion, error) {
+	return c.autocomplete(namedAutocompleteSource("thurston_officers", "", "o.title", ""), prefix, limit)
+}
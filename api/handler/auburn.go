@@ -24,11 +24,51 @@ func (h *Handler) AuburnOfficerMetadata(w http.ResponseWriter, r *http.Request)
 func (h *Handler) AuburnStrictMatch(w http.ResponseWriter, r *http.Request) {
 	badge, firstName, lastName := r.URL.Query().Get("badge"), r.URL.Query().Get("first_name"), r.URL.Query().Get("last_name")
 
+	ranks, err := parseRanks(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	filters, err := parseFilters(r, "title")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	badgeSearch, err := parseBadgeSearch(r, badge)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	nicknames, err := parseNicknames(r, firstName)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
 	if badge != "" {
-		h.auburnGetOfficerByBadge(badge, w)
+		h.auburnGetOfficerByBadge(badgeSearch, ranks, w)
 		return
 	} else if firstName != "" || lastName != "" {
-		h.auburnGetOfficersByName(firstName, lastName, w)
+		h.auburnGetOfficersByName(firstName, lastName, nicknames, filters, ranks, w)
 		return
 	} else {
 		w.WriteHeader(http.StatusBadRequest)
@@ -39,7 +79,7 @@ func (h *Handler) AuburnStrictMatch(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func (h *Handler) auburnGetOfficerByBadge(badge string, w http.ResponseWriter) {
+func (h *Handler) auburnGetOfficerByBadge(badge data.BadgeSearch, ranks []string, w http.ResponseWriter) {
 	officers, err := h.db.AuburnGetOfficerByBadge(badge)
 
 	if err != nil {
@@ -51,12 +91,17 @@ func (h *Handler) auburnGetOfficerByBadge(badge string, w http.ResponseWriter) {
 		return
 	}
 
-	sort.Slice(officers, func(a, b int) bool {
-		if officers[a].LastName == officers[b].LastName {
-			return officers[a].FirstName < officers[b].FirstName
-		}
-		return officers[a].LastName < officers[b].LastName
-	})
+	// matches found by partial or confusable badges keep their ranking
+	if badge.Mode == data.BadgeModeExact {
+		sort.Slice(officers, func(a, b int) bool {
+			if officers[a].LastName == officers[b].LastName {
+				return officers[a].FirstName < officers[b].FirstName
+			}
+			return officers[a].LastName < officers[b].LastName
+		})
+	}
+
+	officers = auburnFilterRanks(officers, ranks)
 
 	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
@@ -66,20 +111,11 @@ func (h *Handler) auburnGetOfficerByBadge(badge string, w http.ResponseWriter) {
 	}
 }
 
-func (h *Handler) auburnGetOfficersByName(firstName, lastName string, w http.ResponseWriter) {
-	if firstName == "" {
-		firstName = "%"
-	} else {
-		firstName = strings.ReplaceAll(firstName, "*", "%")
-	}
-
-	if lastName == "" {
-		lastName = "%"
-	} else {
-		lastName = strings.ReplaceAll(lastName, "*", "%")
-	}
+func (h *Handler) auburnGetOfficersByName(firstName, lastName string, nicknames []string, filters data.Filters, ranks []string, w http.ResponseWriter) {
+	firstName = namePattern(firstName, filters)
+	lastName = namePattern(lastName, filters)
 
-	officers, err := h.db.AuburnSearchOfficerByName(firstName, lastName)
+	officers, err := h.db.AuburnSearchOfficerByName(firstName, lastName, nicknames, filters)
 
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -90,6 +126,8 @@ func (h *Handler) auburnGetOfficersByName(firstName, lastName string, w http.Res
 		return
 	}
 
+	officers = auburnFilterRanks(officers, ranks)
+
 	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(&officers)
@@ -105,12 +143,42 @@ func (h *Handler) AuburnFuzzySearch(w http.ResponseWriter, r *http.Request) {
 	officers := []*data.AuburnOfficer{}
 	var err error
 
+	ranks, err := parseRanks(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	opts, err := parseFuzzyOptions(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	opts.Nicknames, err = parseNicknames(r, firstName)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
 	if firstName != "" && lastName != "" {
-		officers, err = h.db.AuburnFuzzySearchByName(strings.Trim(firstName+" "+lastName, " "))
+		officers, err = h.db.AuburnFuzzySearchByName(strings.Trim(firstName+" "+lastName, " "), opts)
 	} else if firstName != "" {
-		officers, err = h.db.AuburnFuzzySearchByFirstName(firstName)
+		officers, err = h.db.AuburnFuzzySearchByFirstName(firstName, opts)
 	} else if lastName != "" {
-		officers, err = h.db.AuburnFuzzySearchByLastName(lastName)
+		officers, err = h.db.AuburnFuzzySearchByLastName(lastName, opts)
 	} else {
 		w.WriteHeader(http.StatusBadRequest)
 		_, writerErr := w.Write([]byte("at least one of the following parameters must be provided: first_name, last_name"))
@@ -129,6 +197,8 @@ func (h *Handler) AuburnFuzzySearch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	officers = auburnFilterRanks(officers, ranks)
+
 	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(&officers)
@@ -136,3 +206,24 @@ func (h *Handler) AuburnFuzzySearch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// AuburnHeadcount is the handler function for retrieving Auburn PD headcounts for each roster date
+func (h *Handler) AuburnHeadcount(w http.ResponseWriter, r *http.Request) {
+	h.headcount(w, r, []string{"title"}, h.db.AuburnHeadcountByDate)
+}
+
+// auburnFilterRanks drops officers whose canonical rank was not requested
+func auburnFilterRanks(officers []*data.AuburnOfficer, ranks []string) []*data.AuburnOfficer {
+	filtered := []*data.AuburnOfficer{}
+	for _, officer := range officers {
+		if matchesRanks(ranks, officer.CanonicalRank) {
+			filtered = append(filtered, officer)
+		}
+	}
+	return filtered
+}
+
+// AuburnAutocomplete is the handler function for suggesting APD officer names as they're typed
+func (h *Handler) AuburnAutocomplete(w http.ResponseWriter, r *http.Request) {
+	h.autocomplete(w, r, h.db.AuburnAutocomplete)
+}
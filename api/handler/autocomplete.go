@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/OrcaCollective/spd-lookup/api/data"
+)
+
+// autocomplete writes the name suggestions returned by suggest for the prefix parameter.
+// The prefix is matched literally, so wildcards typed into a search box aren't expanded.
+func (h *Handler) autocomplete(w http.ResponseWriter, r *http.Request, suggest func(prefix string, limit int) ([]*data.OfficerSuggestion, error)) {
+	prefix := strings.TrimSpace(r.URL.Query().Get("prefix"))
+	limit, err := parseAutocompleteLimit(r)
+
+	if err == nil && prefix == "" {
+		err = fmt.Errorf("prefix is required")
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	suggestions, err := suggest(likePrefix(prefix), limit)
+
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, writeErr := w.Write([]byte(fmt.Sprintf("error getting suggestions: %s", err)))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(&suggestions)
+	if err != nil {
+		return
+	}
+}
+
+// parseAutocompleteLimit reads the optional limit parameter, defaulting to
+// data.AutocompleteLimitDefault suggestions.
+func parseAutocompleteLimit(r *http.Request) (int, error) {
+	value := strings.TrimSpace(r.URL.Query().Get("limit"))
+	if value == "" {
+		return data.AutocompleteLimitDefault, nil
+	}
+
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 1 || limit > data.AutocompleteLimitMax {
+		return 0, fmt.Errorf("limit must be a whole number between 1 and %d", data.AutocompleteLimitMax)
+	}
+	return limit, nil
+}
+
+// likePrefix translates prefix into a lowercase LIKE pattern matching the values starting
+// with it. Lowercasing the pattern up front lets Postgres use the prefix indexes on the
+// lowercased names.
+func likePrefix(prefix string) string {
+	var like strings.Builder
+	for _, c := range strings.ToLower(prefix) {
+		writeLikeLiteral(&like, c)
+	}
+	like.WriteRune('%')
+	return like.String()
+}
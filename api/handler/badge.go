@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/OrcaCollective/spd-lookup/api/data"
+)
+
+// parseBadgeSearch reads the badge_mode parameter and describes the badges matched by a badge
+// lookup. Badges are matched as wildcard patterns by default. In partial mode a badge without
+// wildcards also matches the badges containing it, so "624" finds "6248", and in confusable
+// mode badges match when a single character was misread, e.g. "62S8" finds "6258".
+func parseBadgeSearch(r *http.Request, badge string) (data.BadgeSearch, error) {
+	search := data.BadgeSearch{Badge: strings.TrimSpace(badge), Mode: data.BadgeModeExact}
+
+	if mode := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("badge_mode"))); mode != "" {
+		if !contains(data.BadgeModes, mode) {
+			return search, fmt.Errorf("badge_mode must be one of the following: %s", strings.Join(data.BadgeModes, ", "))
+		}
+		search.Mode = mode
+	}
+
+	search.Pattern = globToLike(data.NormalizeBadgePattern(search.Badge))
+	if search.Mode == data.BadgeModePartial && !hasWildcards(search.Badge) {
+		search.Pattern = "%" + search.Pattern + "%"
+	}
+	return search, nil
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/OrcaCollective/spd-lookup/api/data"
+)
+
+func TestParseBadgeSearch(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		badge   string
+		mode    string
+		pattern string
+		err     string
+	}{
+		{name: "Exact", badge: "6248", pattern: "6248"},
+		{name: "LeadingZeros", badge: "#06248", pattern: "6248"},
+		{name: "Wildcards", badge: "62?8*", pattern: "62_8%"},
+		{name: "LeadingZeroWildcard", badge: "0*", pattern: "0%"},
+		{name: "Partial", badge: "624", mode: data.BadgeModePartial, pattern: "%624%"},
+		{name: "PartialWildcards", badge: "62?", mode: data.BadgeModePartial, pattern: "62_"},
+		{name: "InvalidMode", badge: "6248", mode: "fuzzy", err: "badge_mode must be one of the following: exact, partial, confusable"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/seattle/officer?badge_mode="+url.QueryEscape(tt.mode), nil)
+			search, err := parseBadgeSearch(r, tt.badge)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Fatalf("error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if search.Pattern != tt.pattern {
+				t.Errorf("pattern = %q, want %q", search.Pattern, tt.pattern)
+			}
+			if search.Pattern == "%" {
+				t.Errorf("pattern for %q matches every badge", tt.badge)
+			}
+		})
+	}
+}
@@ -23,10 +23,50 @@ func (h *Handler) BellevueOfficerMetadata(w http.ResponseWriter, r *http.Request
 func (h *Handler) BellevueStrictMatch(w http.ResponseWriter, r *http.Request) {
 	badge, firstName, lastName := r.URL.Query().Get("badge"), r.URL.Query().Get("first_name"), r.URL.Query().Get("last_name")
 
+	ranks, err := parseRanks(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	filters, err := parseFilters(r, "title", "unit")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	badgeSearch, err := parseBadgeSearch(r, badge)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	nicknames, err := parseNicknames(r, firstName)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
 	if badge != "" {
-		h.bellevueGetOfficersByBadge(badge, w)
+		h.bellevueGetOfficersByBadge(badgeSearch, ranks, w)
 	} else if firstName != "" || lastName != "" {
-		h.bellevueGetOfficersByName(firstName, lastName, w)
+		h.bellevueGetOfficersByName(firstName, lastName, nicknames, filters, ranks, w)
 		return
 	} else {
 		w.WriteHeader(http.StatusBadRequest)
@@ -37,7 +77,7 @@ func (h *Handler) BellevueStrictMatch(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func (h *Handler) bellevueGetOfficersByBadge(badge string, w http.ResponseWriter) {
+func (h *Handler) bellevueGetOfficersByBadge(badge data.BadgeSearch, ranks []string, w http.ResponseWriter) {
 	officers, err := h.db.BellevueSearchOfficerByBadge(badge)
 
 	if err != nil {
@@ -49,6 +89,8 @@ func (h *Handler) bellevueGetOfficersByBadge(badge string, w http.ResponseWriter
 		return
 	}
 
+	officers = bellevueFilterRanks(officers, ranks)
+
 	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(&officers)
@@ -57,20 +99,11 @@ func (h *Handler) bellevueGetOfficersByBadge(badge string, w http.ResponseWriter
 	}
 }
 
-func (h *Handler) bellevueGetOfficersByName(firstName, lastName string, w http.ResponseWriter) {
-	if firstName == "" {
-		firstName = "%"
-	} else {
-		firstName = strings.ReplaceAll(firstName, "*", "%")
-	}
-
-	if lastName == "" {
-		lastName = "%"
-	} else {
-		lastName = strings.ReplaceAll(lastName, "*", "%")
-	}
+func (h *Handler) bellevueGetOfficersByName(firstName, lastName string, nicknames []string, filters data.Filters, ranks []string, w http.ResponseWriter) {
+	firstName = namePattern(firstName, filters)
+	lastName = namePattern(lastName, filters)
 
-	officers, err := h.db.BellevueSearchOfficerByName(firstName, lastName)
+	officers, err := h.db.BellevueSearchOfficerByName(firstName, lastName, nicknames, filters)
 
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -81,6 +114,8 @@ func (h *Handler) bellevueGetOfficersByName(firstName, lastName string, w http.R
 		return
 	}
 
+	officers = bellevueFilterRanks(officers, ranks)
+
 	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(&officers)
@@ -96,12 +131,42 @@ func (h *Handler) BellevueFuzzySearch(w http.ResponseWriter, r *http.Request) {
 	officers := []*data.BellevueOfficer{}
 	var err error
 
+	ranks, err := parseRanks(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	opts, err := parseFuzzyOptions(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	opts.Nicknames, err = parseNicknames(r, firstName)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
 	if firstName != "" && lastName != "" {
-		officers, err = h.db.BellevueFuzzySearchByName(strings.Trim(firstName+" "+lastName, " "))
+		officers, err = h.db.BellevueFuzzySearchByName(strings.Trim(firstName+" "+lastName, " "), opts)
 	} else if firstName != "" {
-		officers, err = h.db.BellevueFuzzySearchByFirstName(firstName)
+		officers, err = h.db.BellevueFuzzySearchByFirstName(firstName, opts)
 	} else if lastName != "" {
-		officers, err = h.db.BellevueFuzzySearchByLastName(lastName)
+		officers, err = h.db.BellevueFuzzySearchByLastName(lastName, opts)
 	} else {
 		w.WriteHeader(http.StatusBadRequest)
 		_, writerErr := w.Write([]byte("at least one of the following parameters must be provided: first_name, last_name"))
@@ -120,6 +185,8 @@ func (h *Handler) BellevueFuzzySearch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	officers = bellevueFilterRanks(officers, ranks)
+
 	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(&officers)
@@ -127,3 +194,19 @@ func (h *Handler) BellevueFuzzySearch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// bellevueFilterRanks drops officers whose canonical rank was not requested
+func bellevueFilterRanks(officers []*data.BellevueOfficer, ranks []string) []*data.BellevueOfficer {
+	filtered := []*data.BellevueOfficer{}
+	for _, officer := range officers {
+		if matchesRanks(ranks, officer.CanonicalRank) {
+			filtered = append(filtered, officer)
+		}
+	}
+	return filtered
+}
+
+// BellevueAutocomplete is the handler function for suggesting BPD officer names as they're typed
+func (h *Handler) BellevueAutocomplete(w http.ResponseWriter, r *http.Request) {
+	h.autocomplete(w, r, h.db.BellevueAutocomplete)
+}
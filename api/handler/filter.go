@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/OrcaCollective/spd-lookup/api/data"
+)
+
+// strictNameParams lists the name parameters of the strict searches, validated alongside
+// the filters when searching by regular expression.
+var strictNameParams = []string{"first_name", "last_name"}
+
+// parseFilters reads the strict search mode and the optional filter parameters supported
+// by a department's strict search. Filters are translated into LIKE patterns, or validated
+// as regular expressions along with the names searched when mode=regex is requested.
+// Parameters left empty are skipped.
+func parseFilters(r *http.Request, params ...string) (data.Filters, error) {
+	filters := data.Filters{Patterns: map[string]string{}}
+
+	mode := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("mode")))
+	if mode != "" && !contains(data.StrictModes, mode) {
+		return filters, fmt.Errorf("mode must be one of the following: %s", strings.Join(data.StrictModes, ", "))
+	}
+	filters.Regex = mode == data.StrictModeRegex
+
+	if filters.Regex {
+		for _, param := range strictNameParams {
+			if err := validateRegex(param, r.URL.Query().Get(param)); err != nil {
+				return filters, err
+			}
+		}
+	}
+
+	for _, param := range params {
+		value := strings.TrimSpace(r.URL.Query().Get(param))
+		if value == "" {
+			continue
+		}
+		if filters.Regex {
+			if err := validateRegex(param, value); err != nil {
+				return filters, err
+			}
+			filters.Patterns[param] = value
+		} else {
+			filters.Patterns[param] = globToLike(value)
+		}
+	}
+	return filters, nil
+}
+
+// parseFlags reads the optional yes/no filter parameters supported by a department's strict
+// search into filters. Each accepts true or false, and parameters left empty are skipped.
+func parseFlags(r *http.Request, filters data.Filters, params ...string) (data.Filters, error) {
+	filters.Flags = map[string]bool{}
+	for _, param := range params {
+		value := strings.TrimSpace(r.URL.Query().Get(param))
+		if value == "" {
+			continue
+		}
+		flag, err := strconv.ParseBool(strings.ToLower(value))
+		if err != nil {
+			return filters, fmt.Errorf("%s must be true or false", param)
+		}
+		filters.Flags[param] = flag
+	}
+	return filters, nil
+}
+
+// parseRanges reads the optional numeric range filters supported by a department's strict
+// search into filters. Each param is bounded through param_min and param_max, either of
+// which may be left out to leave the range open ended.
+func parseRanges(r *http.Request, filters data.Filters, params ...string) (data.Filters, error) {
+	filters.Ranges = map[string]data.Range{}
+	for _, param := range params {
+		min, err := parseBound(r, param+"_min")
+		if err != nil {
+			return filters, err
+		}
+		max, err := parseBound(r, param+"_max")
+		if err != nil {
+			return filters, err
+		}
+
+		if min == nil && max == nil {
+			continue
+		}
+		if min != nil && max != nil && *min > *max {
+			return filters, fmt.Errorf("%s_min must not be greater than %s_max", param, param)
+		}
+		filters.Ranges[param] = data.Range{Min: min, Max: max}
+	}
+	return filters, nil
+}
+
+// parseBound reads the optional numeric parameter bounding a range filter
+func parseBound(r *http.Request, param string) (*float64, error) {
+	value := strings.TrimSpace(r.URL.Query().Get(param))
+	if value == "" {
+		return nil, nil
+	}
+	bound, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be a number", param)
+	}
+	return &bound, nil
+}
+
+// namePattern translates a name searched by a strict search into the pattern matched
+// against the roster, matching every name when none was provided.
+func namePattern(name string, filters data.Filters) string {
+	if filters.Regex {
+		return name
+	}
+	if name == "" {
+		return "%"
+	}
+	return globToLike(name)
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/OrcaCollective/spd-lookup/api/data"
+)
+
+func TestParseFilters(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		query   string
+		filters data.Filters
+		err     string
+	}{
+		{name: "NoFilters", query: "", filters: data.Filters{Patterns: map[string]string{}}},
+		{name: "Filters", query: "title=Sergeant&unit=+", filters: data.Filters{Patterns: map[string]string{"title": "Sergeant"}}},
+		{name: "Wildcards", query: "title=*sergeant*", filters: data.Filters{Patterns: map[string]string{"title": "%sergeant%"}}},
+		{name: "EscapedLike", query: "title=Sgt_1", filters: data.Filters{Patterns: map[string]string{"title": "Sgt\\_1"}}},
+		{name: "WildcardMode", query: "mode=wildcard&title=Sgt*", filters: data.Filters{Patterns: map[string]string{"title": "Sgt%"}}},
+		{name: "Regex", query: "mode=REGEX&title=^Sgt&last_name=^Sm", filters: data.Filters{Patterns: map[string]string{"title": "^Sgt"}, Regex: true}},
+		{name: "UnsupportedParam", query: "rank=Sergeant", filters: data.Filters{Patterns: map[string]string{}}},
+		{name: "InvalidMode", query: "mode=fuzzy", err: "mode must be one of the following: wildcard, regex"},
+		{name: "InvalidRegexFilter", query: "mode=regex&title=(a%2B)%2B", err: "title is too complex a regular expression: nested repetition, repetition counts above 20 and more than 50 terms are not supported"},
+		{name: "InvalidRegexName", query: "mode=regex&first_name=Sm(ith", err: "first_name must be a valid regular expression: error parsing regexp: missing closing ): `Sm(ith`"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			filters, err := parseFilters(httptest.NewRequest("GET", "/seattle/officer?"+tt.query, nil), "title", "unit")
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Fatalf("error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(filters, tt.filters) {
+				t.Errorf("filters = %+v, want %+v", filters, tt.filters)
+			}
+		})
+	}
+}
+
+func TestParseFlags(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		query string
+		flags map[string]bool
+		err   string
+	}{
+		{name: "NoFlags", query: "", flags: map[string]bool{}},
+		{name: "Flags", query: "rrt=true&involved_in_ois_uof=FALSE", flags: map[string]bool{"rrt": true, "involved_in_ois_uof": false}},
+		{name: "UnsupportedParam", query: "sound_truck_training_2020=true", flags: map[string]bool{}},
+		{name: "Invalid", query: "rrt=yes", err: "rrt must be true or false"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			filters, err := parseFlags(httptest.NewRequest("GET", "/portland/officer?"+tt.query, nil), data.Filters{}, "rrt", "involved_in_ois_uof")
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Fatalf("error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(filters.Flags, tt.flags) {
+				t.Errorf("flags = %v, want %v", filters.Flags, tt.flags)
+			}
+		})
+	}
+}
+
+func TestParseRanges(t *testing.T) {
+	bound := func(value float64) *float64 { return &value }
+	for _, tt := range []struct {
+		name   string
+		query  string
+		ranges map[string]data.Range
+		err    string
+	}{
+		{name: "NoRanges", query: "", ranges: map[string]data.Range{}},
+		{name: "Range", query: "hire_year_min=2010&hire_year_max=2015", ranges: map[string]data.Range{"hire_year": {Min: bound(2010), Max: bound(2015)}}},
+		{name: "OpenEnded", query: "hire_year_max=2015", ranges: map[string]data.Range{"hire_year": {Max: bound(2015)}}},
+		{name: "Salary", query: "salary_min=100000.50", ranges: map[string]data.Range{"salary": {Min: bound(100000.5)}}},
+		{name: "EqualBounds", query: "hire_year_min=2010&hire_year_max=2010", ranges: map[string]data.Range{"hire_year": {Min: bound(2010), Max: bound(2010)}}},
+		{name: "Reversed", query: "hire_year_min=2015&hire_year_max=2010", err: "hire_year_min must not be greater than hire_year_max"},
+		{name: "NotANumber", query: "hire_year_max=recent", err: "hire_year_max must be a number"},
+		{name: "SalaryNotANumber", query: "salary_max=lots", err: "salary_max must be a number"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			filters, err := parseRanges(httptest.NewRequest("GET", "/portland/officer?"+tt.query, nil), data.Filters{}, "hire_year", "salary")
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Fatalf("error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(filters.Ranges, tt.ranges) {
+				t.Errorf("ranges = %v, want %v", filters.Ranges, tt.ranges)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/OrcaCollective/spd-lookup/api/data"
+)
+
+// parseFuzzyOptions reads the parameters tuning a fuzzy name search. The mode parameter
+// defaults to trigram matching when omitted, min_score to keeping every match, sort to
+// ordering by relevance and threshold to the default similarity threshold.
+func parseFuzzyOptions(r *http.Request) (data.FuzzyOptions, error) {
+	opts := data.FuzzyOptions{Mode: data.FuzzyModeTrigram, Sort: data.FuzzySortRelevance}
+
+	if mode := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("mode"))); mode != "" {
+		if !contains(data.FuzzyModes, mode) {
+			return opts, fmt.Errorf("mode must be one of the following: %s", strings.Join(data.FuzzyModes, ", "))
+		}
+		opts.Mode = mode
+	}
+
+	if minScore := strings.TrimSpace(r.URL.Query().Get("min_score")); minScore != "" {
+		score, err := strconv.ParseFloat(minScore, 64)
+		if err != nil || score < 0 || score > 1 {
+			return opts, fmt.Errorf("min_score must be a number between 0 and 1")
+		}
+		opts.MinScore = score
+	}
+
+	if sort := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("sort"))); sort != "" {
+		if !contains(data.FuzzySorts, sort) {
+			return opts, fmt.Errorf("sort must be one of the following: %s", strings.Join(data.FuzzySorts, ", "))
+		}
+		opts.Sort = sort
+	}
+
+	if threshold := strings.TrimSpace(r.URL.Query().Get("threshold")); threshold != "" {
+		value, err := strconv.ParseFloat(threshold, 64)
+		if err != nil || value <= 0 || value > 1 {
+			return opts, fmt.Errorf("threshold must be a number greater than 0 and at most 1")
+		}
+		opts.Threshold = value
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,55 @@
+package handler
+
+import "strings"
+
+// globToLike translates a strict search pattern into a LIKE pattern. Patterns use a small
+// glob grammar: `*` matches any run of characters, `?` matches a single character and a
+// backslash matches the character following it literally. Characters LIKE treats specially
+// are escaped, so `%` and `_` only ever match themselves.
+func globToLike(glob string) string {
+	var like strings.Builder
+	escaped := false
+	for _, c := range glob {
+		switch {
+		case escaped:
+			escaped = false
+			writeLikeLiteral(&like, c)
+		case c == '\\':
+			escaped = true
+		case c == '*':
+			like.WriteRune('%')
+		case c == '?':
+			like.WriteRune('_')
+		default:
+			writeLikeLiteral(&like, c)
+		}
+	}
+	if escaped {
+		writeLikeLiteral(&like, '\\')
+	}
+	return like.String()
+}
+
+// writeLikeLiteral writes c to a LIKE pattern so that it only matches itself
+func writeLikeLiteral(like *strings.Builder, c rune) {
+	if c == '%' || c == '_' || c == '\\' {
+		like.WriteRune('\\')
+	}
+	like.WriteRune(c)
+}
+
+// hasWildcards reports whether a strict search pattern contains unescaped wildcards
+func hasWildcards(glob string) bool {
+	escaped := false
+	for _, c := range glob {
+		switch {
+		case escaped:
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case c == '*' || c == '?':
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package handler
+
+import "testing"
+
+func TestGlobToLike(t *testing.T) {
+	for _, tt := range []struct {
+		glob string
+		like string
+	}{
+		{"", ""},
+		{"Smith", "Smith"},
+		{"Sm*th", "Sm%th"},
+		{"Sm?th", "Sm_th"},
+		{"*", "%"},
+		{"100%", "100\\%"},
+		{"first_name", "first\\_name"},
+		{"\\*", "*"},
+		{"\\?", "?"},
+		{"a\\\\b", "a\\\\b"},
+		{"trailing\\", "trailing\\\\"},
+	} {
+		t.Run(tt.glob, func(t *testing.T) {
+			if like := globToLike(tt.glob); like != tt.like {
+				t.Errorf("globToLike(%q) = %q, want %q", tt.glob, like, tt.like)
+			}
+		})
+	}
+}
+
+func TestHasWildcards(t *testing.T) {
+	for _, tt := range []struct {
+		glob      string
+		wildcards bool
+	}{
+		{"", false},
+		{"Smith", false},
+		{"Sm*th", true},
+		{"Sm?th", true},
+		{"\\*", false},
+		{"\\\\*", true},
+		{"100%", false},
+	} {
+		t.Run(tt.glob, func(t *testing.T) {
+			if wildcards := hasWildcards(tt.glob); wildcards != tt.wildcards {
+				t.Errorf("hasWildcards(%q) = %t, want %t", tt.glob, wildcards, tt.wildcards)
+			}
+		})
+	}
+}
@@ -15,33 +15,55 @@ type Interface interface {
 	SeattleStrictMatch(w http.ResponseWriter, r *http.Request)
 	SeattleStrictMatchHistorical(w http.ResponseWriter, r *http.Request)
 	SeattleFuzzySearch(w http.ResponseWriter, r *http.Request)
+	SeattleAutocomplete(w http.ResponseWriter, r *http.Request)
+	SeattleHeadcount(w http.ResponseWriter, r *http.Request)
+	SeattlePromotionsReport(w http.ResponseWriter, r *http.Request)
+	SeattlePrecincts(w http.ResponseWriter, r *http.Request)
 	TacomaOfficerMetadata(w http.ResponseWriter, r *http.Request)
 	TacomaStrictMatch(w http.ResponseWriter, r *http.Request)
 	TacomaFuzzySearch(w http.ResponseWriter, r *http.Request)
+	TacomaAutocomplete(w http.ResponseWriter, r *http.Request)
+	TacomaHeadcount(w http.ResponseWriter, r *http.Request)
+	TacomaSalary(w http.ResponseWriter, r *http.Request)
 	PortlandOfficerMetadata(w http.ResponseWriter, r *http.Request)
 	PortlandStrictMatch(w http.ResponseWriter, r *http.Request)
+	PortlandStrictMatchHistorical(w http.ResponseWriter, r *http.Request)
+	PortlandRRTMembers(w http.ResponseWriter, r *http.Request)
 	PortlandFuzzySearch(w http.ResponseWriter, r *http.Request)
+	PortlandAutocomplete(w http.ResponseWriter, r *http.Request)
+	PortlandHeadcount(w http.ResponseWriter, r *http.Request)
+	PortlandSalary(w http.ResponseWriter, r *http.Request)
 	AuburnOfficerMetadata(w http.ResponseWriter, r *http.Request)
 	AuburnStrictMatch(w http.ResponseWriter, r *http.Request)
 	AuburnFuzzySearch(w http.ResponseWriter, r *http.Request)
+	AuburnAutocomplete(w http.ResponseWriter, r *http.Request)
+	AuburnHeadcount(w http.ResponseWriter, r *http.Request)
 	LakewoodOfficerMetadata(w http.ResponseWriter, r *http.Request)
 	LakewoodStrictMatch(w http.ResponseWriter, r *http.Request)
 	LakewoodFuzzySearch(w http.ResponseWriter, r *http.Request)
+	LakewoodAutocomplete(w http.ResponseWriter, r *http.Request)
+	LakewoodHeadcount(w http.ResponseWriter, r *http.Request)
 	BellevueOfficerMetadata(w http.ResponseWriter, r *http.Request)
 	BellevueStrictMatch(w http.ResponseWriter, r *http.Request)
 	BellevueFuzzySearch(w http.ResponseWriter, r *http.Request)
+	BellevueAutocomplete(w http.ResponseWriter, r *http.Request)
 	ThurstonCountyOfficerMetadata(w http.ResponseWriter, r *http.Request)
 	ThurstonCountyStrictMatch(w http.ResponseWriter, r *http.Request)
 	ThurstonCountyFuzzySearch(w http.ResponseWriter, r *http.Request)
+	ThurstonCountyAutocomplete(w http.ResponseWriter, r *http.Request)
 	PortOfSeattleOfficerMetadata(w http.ResponseWriter, r *http.Request)
 	PortOfSeattleStrictMatch(w http.ResponseWriter, r *http.Request)
 	PortOfSeattleFuzzySearch(w http.ResponseWriter, r *http.Request)
+	PortOfSeattleAutocomplete(w http.ResponseWriter, r *http.Request)
 	RentonOfficerMetadata(w http.ResponseWriter, r *http.Request)
 	RentonStrictMatch(w http.ResponseWriter, r *http.Request)
 	RentonFuzzySearch(w http.ResponseWriter, r *http.Request)
+	RentonAutocomplete(w http.ResponseWriter, r *http.Request)
 	OlympiaOfficerMetadata(w http.ResponseWriter, r *http.Request)
 	OlympiaStrictMatch(w http.ResponseWriter, r *http.Request)
 	OlympiaFuzzySearch(w http.ResponseWriter, r *http.Request)
+	OlympiaAutocomplete(w http.ResponseWriter, r *http.Request)
+	OlympiaHeadcount(w http.ResponseWriter, r *http.Request)
 }
 
 // Handler is the struct for route handler functions
@@ -23,8 +23,38 @@ func (h *Handler) LakewoodOfficerMetadata(w http.ResponseWriter, r *http.Request
 func (h *Handler) LakewoodStrictMatch(w http.ResponseWriter, r *http.Request) {
 	firstName, lastName := r.URL.Query().Get("first_name"), r.URL.Query().Get("last_name")
 
+	ranks, err := parseRanks(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	filters, err := parseFilters(r, "title", "unit", "unit_description")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	nicknames, err := parseNicknames(r, firstName)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
 	if firstName != "" || lastName != "" {
-		h.lakewoodGetOfficersByName(firstName, lastName, w)
+		h.lakewoodGetOfficersByName(firstName, lastName, nicknames, filters, ranks, w)
 		return
 	} else {
 		w.WriteHeader(http.StatusBadRequest)
@@ -35,20 +65,11 @@ func (h *Handler) LakewoodStrictMatch(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func (h *Handler) lakewoodGetOfficersByName(firstName, lastName string, w http.ResponseWriter) {
-	if firstName == "" {
-		firstName = "%"
-	} else {
-		firstName = strings.ReplaceAll(firstName, "*", "%")
-	}
-
-	if lastName == "" {
-		lastName = "%"
-	} else {
-		lastName = strings.ReplaceAll(lastName, "*", "%")
-	}
+func (h *Handler) lakewoodGetOfficersByName(firstName, lastName string, nicknames []string, filters data.Filters, ranks []string, w http.ResponseWriter) {
+	firstName = namePattern(firstName, filters)
+	lastName = namePattern(lastName, filters)
 
-	officers, err := h.db.LakewoodSearchOfficerByName(firstName, lastName)
+	officers, err := h.db.LakewoodSearchOfficerByName(firstName, lastName, nicknames, filters)
 
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -59,6 +80,8 @@ func (h *Handler) lakewoodGetOfficersByName(firstName, lastName string, w http.R
 		return
 	}
 
+	officers = lakewoodFilterRanks(officers, ranks)
+
 	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(&officers)
@@ -74,12 +97,42 @@ func (h *Handler) LakewoodFuzzySearch(w http.ResponseWriter, r *http.Request) {
 	officers := []*data.LakewoodOfficer{}
 	var err error
 
+	ranks, err := parseRanks(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	opts, err := parseFuzzyOptions(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	opts.Nicknames, err = parseNicknames(r, firstName)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
 	if firstName != "" && lastName != "" {
-		officers, err = h.db.LakewoodFuzzySearchByName(strings.Trim(firstName+" "+lastName, " "))
+		officers, err = h.db.LakewoodFuzzySearchByName(strings.Trim(firstName+" "+lastName, " "), opts)
 	} else if firstName != "" {
-		officers, err = h.db.LakewoodFuzzySearchByFirstName(firstName)
+		officers, err = h.db.LakewoodFuzzySearchByFirstName(firstName, opts)
 	} else if lastName != "" {
-		officers, err = h.db.LakewoodFuzzySearchByLastName(lastName)
+		officers, err = h.db.LakewoodFuzzySearchByLastName(lastName, opts)
 	} else {
 		w.WriteHeader(http.StatusBadRequest)
 		_, writerErr := w.Write([]byte("at least one of the following parameters must be provided: first_name, last_name"))
@@ -98,6 +151,8 @@ func (h *Handler) LakewoodFuzzySearch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	officers = lakewoodFilterRanks(officers, ranks)
+
 	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(&officers)
@@ -105,3 +160,24 @@ func (h *Handler) LakewoodFuzzySearch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// LakewoodHeadcount is the handler function for retrieving Lakewood PD headcounts for each roster date
+func (h *Handler) LakewoodHeadcount(w http.ResponseWriter, r *http.Request) {
+	h.headcount(w, r, []string{"title", "unit"}, h.db.LakewoodHeadcountByDate)
+}
+
+// lakewoodFilterRanks drops officers whose canonical rank was not requested
+func lakewoodFilterRanks(officers []*data.LakewoodOfficer, ranks []string) []*data.LakewoodOfficer {
+	filtered := []*data.LakewoodOfficer{}
+	for _, officer := range officers {
+		if matchesRanks(ranks, officer.CanonicalRank) {
+			filtered = append(filtered, officer)
+		}
+	}
+	return filtered
+}
+
+// LakewoodAutocomplete is the handler function for suggesting LPD officer names as they're typed
+func (h *Handler) LakewoodAutocomplete(w http.ResponseWriter, r *http.Request) {
+	h.autocomplete(w, r, h.db.LakewoodAutocomplete)
+}
@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/OrcaCollective/spd-lookup/api/data"
+)
+
+// parseNicknames returns the nicknames searched alongside firstName, unless the search opted
+// out of them with nicknames=false. First names containing wildcards are not expanded, nor
+// are regular expressions searched with mode=regex.
+func parseNicknames(r *http.Request, firstName string) ([]string, error) {
+	expand := true
+	if value := strings.TrimSpace(r.URL.Query().Get("nicknames")); value != "" {
+		var err error
+		expand, err = strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("nicknames must be one of the following: true, false")
+		}
+	}
+
+	firstName = strings.TrimSpace(firstName)
+	regex := strings.EqualFold(r.URL.Query().Get("mode"), data.StrictModeRegex)
+	if !expand || regex || firstName == "" || hasWildcards(firstName) {
+		return nil, nil
+	}
+	return data.Nicknames(firstName), nil
+}
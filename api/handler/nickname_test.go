@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestParseNicknames(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		query     string
+		firstName string
+		nicknames []string
+		err       string
+	}{
+		{name: "Expanded", firstName: "Bob", nicknames: []string{"bobby", "rob", "robbie", "robert"}},
+		{name: "ExplicitlyExpanded", query: "nicknames=true", firstName: "Bob", nicknames: []string{"bobby", "rob", "robbie", "robert"}},
+		{name: "OptedOut", query: "nicknames=false", firstName: "Bob"},
+		{name: "NoFirstName", firstName: " "},
+		{name: "Wildcards", firstName: "Bo*"},
+		{name: "Regex", query: "mode=regex", firstName: "Bob"},
+		{name: "Invalid", query: "nicknames=maybe", firstName: "Bob", err: "nicknames must be one of the following: true, false"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/seattle/officer?"+tt.query, nil)
+			nicknames, err := parseNicknames(r, tt.firstName)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Fatalf("error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(nicknames, tt.nicknames) {
+				t.Errorf("parseNicknames(%q) = %v, want %v", tt.firstName, nicknames, tt.nicknames)
+			}
+		})
+	}
+}
@@ -21,24 +21,76 @@ func (h *Handler) PortOfSeattleOfficerMetadata(w http.ResponseWriter, r *http.Re
 
 // PortOfSeattleStrictMatch is the handler function for retrieving PortOfSeattle officers with a strict match
 func (h *Handler) PortOfSeattleStrictMatch(w http.ResponseWriter, r *http.Request) {
-	badge, name := r.URL.Query().Get("badge"), r.URL.Query().Get("name")
+	badge, firstName, lastName := r.URL.Query().Get("badge"), r.URL.Query().Get("first_name"), r.URL.Query().Get("last_name")
+
+	ranks, err := parseRanks(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	filters, err := parseFilters(r, "title", "unit")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	filters, err = parseRanges(r, filters, "hire_year")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	badgeSearch, err := parseBadgeSearch(r, badge)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	nicknames, err := parseNicknames(r, firstName)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
 	if badge != "" {
-		h.portOfSeattleGetOfficersByBadge(badge, w)
-	} else if name != "" {
-		h.portOfSeattleGetOfficersByName(name, w)
+		h.portOfSeattleGetOfficersByBadge(badgeSearch, ranks, w)
+	} else if firstName != "" || lastName != "" {
+		h.portOfSeattleGetOfficersByName(firstName, lastName, nicknames, filters, ranks, w)
+		return
+	} else if len(filters.Ranges) > 0 {
+		h.portOfSeattleGetOfficersByFilters(filters, ranks, w)
 		return
 	} else {
 		w.WriteHeader(http.StatusBadRequest)
-		_, err := w.Write([]byte("name must be provided"))
+		_, err := w.Write([]byte("at least one of the following parameters must be provided: badge, first_name, last_name, hire_year_min, hire_year_max"))
 		if err != nil {
 			return
 		}
 	}
 }
 
-func (h *Handler) portOfSeattleGetOfficersByBadge(badge string, w http.ResponseWriter) {
-	badge = strings.ReplaceAll(badge, "*", "%")
-
+func (h *Handler) portOfSeattleGetOfficersByBadge(badge data.BadgeSearch, ranks []string, w http.ResponseWriter) {
 	officers, err := h.db.PortOfSeattleSearchOfficerByBadge(badge)
 
 	if err != nil {
@@ -50,6 +102,8 @@ func (h *Handler) portOfSeattleGetOfficersByBadge(badge string, w http.ResponseW
 		return
 	}
 
+	officers = portOfSeattleFilterRanks(officers, ranks)
+
 	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(&officers)
@@ -58,16 +112,33 @@ func (h *Handler) portOfSeattleGetOfficersByBadge(badge string, w http.ResponseW
 	}
 }
 
-func (h *Handler) portOfSeattleGetOfficersByName(name string, w http.ResponseWriter) {
-	if name == "" {
-		name = "%"
-	} else {
-		name = strings.ReplaceAll(name, "*", "%")
+func (h *Handler) portOfSeattleGetOfficersByName(firstName, lastName string, nicknames []string, filters data.Filters, ranks []string, w http.ResponseWriter) {
+	firstName = namePattern(firstName, filters)
+	lastName = namePattern(lastName, filters)
+
+	officers, err := h.db.PortOfSeattleSearchOfficerByName(firstName, lastName, nicknames, filters)
+
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, errWrite := w.Write([]byte(fmt.Sprintf("error getting officer: %s", err)))
+		if errWrite != nil {
+			return
+		}
+		return
 	}
 
-	name = "%" + name + "%"
+	officers = portOfSeattleFilterRanks(officers, ranks)
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(&officers)
+	if err != nil {
+		return
+	}
+}
 
-	officers, err := h.db.PortOfSeattleSearchOfficerByName(name)
+func (h *Handler) portOfSeattleGetOfficersByFilters(filters data.Filters, ranks []string, w http.ResponseWriter) {
+	officers, err := h.db.PortOfSeattleSearchOfficersByFilters(filters)
 
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -78,6 +149,8 @@ func (h *Handler) portOfSeattleGetOfficersByName(name string, w http.ResponseWri
 		return
 	}
 
+	officers = portOfSeattleFilterRanks(officers, ranks)
+
 	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(&officers)
@@ -86,17 +159,52 @@ func (h *Handler) portOfSeattleGetOfficersByName(name string, w http.ResponseWri
 	}
 }
 
-// PortOfSeattleFuzzySearch is the handler function for retrieving APD officers through fuzzy search
+// PortOfSeattleFuzzySearch is the handler function for retrieving PortOfSeattle officers through fuzzy search
 func (h *Handler) PortOfSeattleFuzzySearch(w http.ResponseWriter, r *http.Request) {
-	name := strings.TrimSpace(r.URL.Query().Get("name"))
+	firstName, lastName := strings.TrimSpace(r.URL.Query().Get("first_name")), strings.TrimSpace(r.URL.Query().Get("last_name"))
+
 	officers := []*data.PortOfSeattleOfficer{}
 	var err error
 
-	if name != "" {
-		officers, err = h.db.PortOfSeattleFuzzySearchByName(name)
+	ranks, err := parseRanks(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	opts, err := parseFuzzyOptions(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	opts.Nicknames, err = parseNicknames(r, firstName)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	if firstName != "" && lastName != "" {
+		officers, err = h.db.PortOfSeattleFuzzySearchByName(strings.Trim(firstName+" "+lastName, " "), opts)
+	} else if firstName != "" {
+		officers, err = h.db.PortOfSeattleFuzzySearchByFirstName(firstName, opts)
+	} else if lastName != "" {
+		officers, err = h.db.PortOfSeattleFuzzySearchByLastName(lastName, opts)
 	} else {
 		w.WriteHeader(http.StatusBadRequest)
-		_, writerErr := w.Write([]byte("provided name must not be empty"))
+		_, writerErr := w.Write([]byte("at least one of the following parameters must be provided: first_name, last_name"))
 		if writerErr != nil {
 			return
 		}
@@ -112,6 +220,8 @@ func (h *Handler) PortOfSeattleFuzzySearch(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	officers = portOfSeattleFilterRanks(officers, ranks)
+
 	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(&officers)
@@ -119,3 +229,19 @@ func (h *Handler) PortOfSeattleFuzzySearch(w http.ResponseWriter, r *http.Reques
 		return
 	}
 }
+
+// portOfSeattleFilterRanks drops officers whose canonical rank was not requested
+func portOfSeattleFilterRanks(officers []*data.PortOfSeattleOfficer, ranks []string) []*data.PortOfSeattleOfficer {
+	filtered := []*data.PortOfSeattleOfficer{}
+	for _, officer := range officers {
+		if matchesRanks(ranks, officer.CanonicalRank) {
+			filtered = append(filtered, officer)
+		}
+	}
+	return filtered
+}
+
+// PortOfSeattleAutocomplete is the handler function for suggesting Port of Seattle officer names as they're typed
+func (h *Handler) PortOfSeattleAutocomplete(w http.ResponseWriter, r *http.Request) {
+	h.autocomplete(w, r, h.db.PortOfSeattleAutocomplete)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/OrcaCollective/spd-lookup/api/data"
@@ -22,6 +23,7 @@ func (h *Handler) PortlandOfficerMetadata(w http.ResponseWriter, r *http.Request
 
 // PortlandStrictMatch is the handler function for retrieving SPD officers with a strict match
 func (h *Handler) PortlandStrictMatch(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimSpace(r.URL.Query().Get("id"))
 	badge := r.URL.Query().Get("badge")
 	firstName := r.URL.Query().Get("first_name")
 	lastName := r.URL.Query().Get("last_name")
@@ -29,31 +31,151 @@ func (h *Handler) PortlandStrictMatch(w http.ResponseWriter, r *http.Request) {
 	helmetId := r.URL.Query().Get("helmet_id")
 	helmetIdThreeDigit := r.URL.Query().Get("helmet_id_three_digit")
 
-	if badge != "" {
-		h.portlandGetOfficersByBadge(badge, w)
+	ranks, err := parseRanks(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	filters, err := parseFilters(r, "title")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	filters, err = parseFlags(r, filters, data.PortlandFlagFilters...)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	filters, err = parseRanges(r, filters, "hire_year", "salary")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	nicknames, err := parseNicknames(r, firstName)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	if id != "" {
+		h.portlandGetOfficersById(id, ranks, w)
+		return
+	} else if badge != "" {
+		h.portlandGetOfficersByBadge(badge, ranks, w)
 		return
 	} else if employeeId != "" {
-		h.portlandGetOfficersByEmployeeId(employeeId, w)
+		h.portlandGetOfficersByEmployeeId(employeeId, ranks, w)
 		return
 	} else if helmetId != "" {
-		h.portlandGetOfficersByHelmetId(helmetId, w)
+		h.portlandGetOfficersByHelmetId(helmetId, ranks, w)
 		return
 	} else if helmetIdThreeDigit != "" {
-		h.portlandGetOfficersByHelmetIdThreeDigit(helmetIdThreeDigit, w)
+		h.portlandGetOfficersByHelmetIdThreeDigit(helmetIdThreeDigit, ranks, w)
 		return
 	} else if firstName != "" || lastName != "" {
-		h.portlandGetOfficersByName(firstName, lastName, w)
+		h.portlandGetOfficersByName(firstName, lastName, nicknames, filters, ranks, w)
+		return
+	} else if len(filters.Flags) > 0 || len(filters.Ranges) > 0 {
+		h.portlandGetOfficersByFilters(filters, ranks, w)
 		return
 	} else {
 		w.WriteHeader(http.StatusBadRequest)
-		_, err := w.Write([]byte("at least one of the following parameters must be provided: badge, first_name, last_name"))
+		_, err := w.Write([]byte("at least one of the following parameters must be provided: id, badge, first_name, last_name, hire_year_min, hire_year_max, salary_min, salary_max, " + strings.Join(data.PortlandFlagFilters, ", ")))
 		if err != nil {
 			return
 		}
 	}
 }
 
-func (h *Handler) portlandGetOfficersByBadge(badge string, w http.ResponseWriter) {
+// PortlandStrictMatchHistorical is the handler function for retrieving PPB officers' roster history with a strict match
+func (h *Handler) PortlandStrictMatchHistorical(w http.ResponseWriter, r *http.Request) {
+	badge := r.URL.Query().Get("badge")
+
+	ranks, err := parseRanks(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	if badge != "" {
+		h.portlandGetOfficerByBadgeHistorical(badge, ranks, w)
+		return
+	} else {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err := w.Write([]byte("at least one of the following parameters must be provided: badge"))
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (h *Handler) portlandGetOfficersById(id string, ranks []string, w http.ResponseWriter) {
+	officers, err := h.db.PortlandSearchOfficersById(id)
+
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			w.WriteHeader(http.StatusOK)
+			w.Header().Set("Content-Type", "application/json")
+			err := json.NewEncoder(w).Encode([]*data.PortlandOfficer{})
+			if err != nil {
+				return
+			}
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		_, err := w.Write([]byte(fmt.Sprintf("error getting officer: %s", err)))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	sort.Slice(officers, func(a, b int) bool {
+		if officers[a].LastName == officers[b].LastName {
+			return officers[a].FirstName < officers[b].FirstName
+		}
+		return officers[a].LastName < officers[b].LastName
+	})
+
+	officers = portlandFilterRanks(officers, ranks)
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(&officers)
+	if err != nil {
+		return
+	}
+}
+
+func (h *Handler) portlandGetOfficersByBadge(badge string, ranks []string, w http.ResponseWriter) {
 	officers, err := h.db.PortlandSearchOfficersByBadge(badge)
 
 	if err != nil {
@@ -76,11 +198,13 @@ func (h *Handler) portlandGetOfficersByBadge(badge string, w http.ResponseWriter
 
 	sort.Slice(officers, func(a, b int) bool {
 		if officers[a].LastName == officers[b].LastName {
-			return officers[a].FirstName.String < officers[b].FirstName.String
+			return officers[a].FirstName < officers[b].FirstName
 		}
-		return officers[a].LastName.String < officers[b].LastName.String
+		return officers[a].LastName < officers[b].LastName
 	})
 
+	officers = portlandFilterRanks(officers, ranks)
+
 	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(&officers)
@@ -89,7 +213,29 @@ func (h *Handler) portlandGetOfficersByBadge(badge string, w http.ResponseWriter
 	}
 }
 
-func (h *Handler) portlandGetOfficersByEmployeeId(employeeId string, w http.ResponseWriter) {
+func (h *Handler) portlandGetOfficerByBadgeHistorical(badge string, ranks []string, w http.ResponseWriter) {
+	officers, err := h.db.PortlandGetOfficerByBadgeHistorical(badge)
+
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, errWrite := w.Write([]byte(fmt.Sprintf("error getting officer: %s", err)))
+		if errWrite != nil {
+			return
+		}
+		return
+	}
+
+	officers = portlandFilterRanks(officers, ranks)
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(&officers)
+	if err != nil {
+		return
+	}
+}
+
+func (h *Handler) portlandGetOfficersByEmployeeId(employeeId string, ranks []string, w http.ResponseWriter) {
 	officers, err := h.db.PortlandSearchOfficersByEmployeeId(employeeId)
 
 	if err != nil {
@@ -112,11 +258,13 @@ func (h *Handler) portlandGetOfficersByEmployeeId(employeeId string, w http.Resp
 
 	sort.Slice(officers, func(a, b int) bool {
 		if officers[a].LastName == officers[b].LastName {
-			return officers[a].FirstName.String < officers[b].FirstName.String
+			return officers[a].FirstName < officers[b].FirstName
 		}
-		return officers[a].LastName.String < officers[b].LastName.String
+		return officers[a].LastName < officers[b].LastName
 	})
 
+	officers = portlandFilterRanks(officers, ranks)
+
 	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(&officers)
@@ -125,7 +273,7 @@ func (h *Handler) portlandGetOfficersByEmployeeId(employeeId string, w http.Resp
 	}
 }
 
-func (h *Handler) portlandGetOfficersByHelmetId(helmetId string, w http.ResponseWriter) {
+func (h *Handler) portlandGetOfficersByHelmetId(helmetId string, ranks []string, w http.ResponseWriter) {
 	officers, err := h.db.PortlandSearchOfficersByHelmetId(helmetId)
 
 	if err != nil {
@@ -148,11 +296,13 @@ func (h *Handler) portlandGetOfficersByHelmetId(helmetId string, w http.Response
 
 	sort.Slice(officers, func(a, b int) bool {
 		if officers[a].LastName == officers[b].LastName {
-			return officers[a].FirstName.String < officers[b].FirstName.String
+			return officers[a].FirstName < officers[b].FirstName
 		}
-		return officers[a].LastName.String < officers[b].LastName.String
+		return officers[a].LastName < officers[b].LastName
 	})
 
+	officers = portlandFilterRanks(officers, ranks)
+
 	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(&officers)
@@ -161,7 +311,7 @@ func (h *Handler) portlandGetOfficersByHelmetId(helmetId string, w http.Response
 	}
 }
 
-func (h *Handler) portlandGetOfficersByHelmetIdThreeDigit(helmetIdThreeDigit string, w http.ResponseWriter) {
+func (h *Handler) portlandGetOfficersByHelmetIdThreeDigit(helmetIdThreeDigit string, ranks []string, w http.ResponseWriter) {
 	officers, err := h.db.PortlandSearchOfficersByHelmetIdThreeDigit(helmetIdThreeDigit)
 
 	if err != nil {
@@ -184,11 +334,13 @@ func (h *Handler) portlandGetOfficersByHelmetIdThreeDigit(helmetIdThreeDigit str
 
 	sort.Slice(officers, func(a, b int) bool {
 		if officers[a].LastName == officers[b].LastName {
-			return officers[a].FirstName.String < officers[b].FirstName.String
+			return officers[a].FirstName < officers[b].FirstName
 		}
-		return officers[a].LastName.String < officers[b].LastName.String
+		return officers[a].LastName < officers[b].LastName
 	})
 
+	officers = portlandFilterRanks(officers, ranks)
+
 	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(&officers)
@@ -197,20 +349,11 @@ func (h *Handler) portlandGetOfficersByHelmetIdThreeDigit(helmetIdThreeDigit str
 	}
 }
 
-func (h *Handler) portlandGetOfficersByName(firstName, lastName string, w http.ResponseWriter) {
-	if firstName == "" {
-		firstName = "%"
-	} else {
-		firstName = strings.ReplaceAll(firstName, "*", "%")
-	}
-
-	if lastName == "" {
-		lastName = "%"
-	} else {
-		lastName = strings.ReplaceAll(lastName, "*", "%")
-	}
+func (h *Handler) portlandGetOfficersByName(firstName, lastName string, nicknames []string, filters data.Filters, ranks []string, w http.ResponseWriter) {
+	firstName = namePattern(firstName, filters)
+	lastName = namePattern(lastName, filters)
 
-	officers, err := h.db.PortlandSearchOfficersByName(firstName, lastName)
+	officers, err := h.db.PortlandSearchOfficersByName(firstName, lastName, nicknames, filters)
 
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -223,11 +366,89 @@ func (h *Handler) portlandGetOfficersByName(firstName, lastName string, w http.R
 
 	sort.Slice(officers, func(a, b int) bool {
 		if officers[a].LastName == officers[b].LastName {
-			return officers[a].FirstName.String < officers[b].FirstName.String
+			return officers[a].FirstName < officers[b].FirstName
 		}
-		return officers[a].LastName.String < officers[b].LastName.String
+		return officers[a].LastName < officers[b].LastName
 	})
 
+	officers = portlandFilterRanks(officers, ranks)
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(&officers)
+	if err != nil {
+		return
+	}
+}
+
+func (h *Handler) portlandGetOfficersByFilters(filters data.Filters, ranks []string, w http.ResponseWriter) {
+	officers, err := h.db.PortlandSearchOfficersByFilters(filters)
+
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, err := w.Write([]byte(fmt.Sprintf("error getting officer: %s", err)))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	officers = portlandFilterRanks(officers, ranks)
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(&officers)
+	if err != nil {
+		return
+	}
+}
+
+// PortlandRRTMembers is the handler function for listing PPB Rapid Response Team members, or
+// the officers with RRT specific training when training=true
+func (h *Handler) PortlandRRTMembers(w http.ResponseWriter, r *http.Request) {
+	training, year, err := parsePortlandRRTYear(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	ranks, err := parseRanks(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	filters, err := parseFlags(r, data.Filters{}, data.PortlandFlagFilters...)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	officers, err := h.db.PortlandSearchRRTOfficers(year, training, filters)
+
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, writeErr := w.Write([]byte(fmt.Sprintf("error getting officer: %s", err)))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	officers = portlandFilterRanks(officers, ranks)
+
 	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(&officers)
@@ -236,6 +457,39 @@ func (h *Handler) portlandGetOfficersByName(firstName, lastName string, w http.R
 	}
 }
 
+// parsePortlandRRTYear reads the optional training and year parameters of the RRT listing.
+// The year is one of data.PortlandRRTYears, or of data.PortlandRRTTrainingYears when
+// training=true. Without a year, members, or trainees, of any year are listed.
+func parsePortlandRRTYear(r *http.Request) (bool, int, error) {
+	training := false
+	if value := strings.TrimSpace(r.URL.Query().Get("training")); value != "" {
+		var err error
+		training, err = strconv.ParseBool(strings.ToLower(value))
+		if err != nil {
+			return false, 0, fmt.Errorf("training must be true or false")
+		}
+	}
+
+	value := strings.TrimSpace(r.URL.Query().Get("year"))
+	if value == "" {
+		return training, 0, nil
+	}
+
+	recorded := data.PortlandRRTYears
+	if training {
+		recorded = data.PortlandRRTTrainingYears
+	}
+	years := make([]string, len(recorded))
+	for i, year := range recorded {
+		years[i] = strconv.Itoa(year)
+	}
+	if !contains(years, value) {
+		return training, 0, fmt.Errorf("year must be one of the following: %s", strings.Join(years, ", "))
+	}
+	year, err := strconv.Atoi(value)
+	return training, year, err
+}
+
 // PortlandFuzzySearch is the handler function for retrieving SPD officers through fuzzy search
 func (h *Handler) PortlandFuzzySearch(w http.ResponseWriter, r *http.Request) {
 	firstName, lastName := strings.TrimSpace(r.URL.Query().Get("first_name")), strings.TrimSpace(r.URL.Query().Get("last_name"))
@@ -243,12 +497,42 @@ func (h *Handler) PortlandFuzzySearch(w http.ResponseWriter, r *http.Request) {
 	officers := []*data.PortlandOfficer{}
 	var err error
 
+	ranks, err := parseRanks(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	opts, err := parseFuzzyOptions(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	opts.Nicknames, err = parseNicknames(r, firstName)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
 	if firstName != "" && lastName != "" {
-		officers, err = h.db.PortlandFuzzySearchByName(strings.Trim(firstName+" "+lastName, " "))
+		officers, err = h.db.PortlandFuzzySearchByName(strings.Trim(firstName+" "+lastName, " "), opts)
 	} else if firstName != "" {
-		officers, err = h.db.PortlandFuzzySearchByFirstName(firstName)
+		officers, err = h.db.PortlandFuzzySearchByFirstName(firstName, opts)
 	} else if lastName != "" {
-		officers, err = h.db.PortlandFuzzySearchByLastName(lastName)
+		officers, err = h.db.PortlandFuzzySearchByLastName(lastName, opts)
 	} else {
 		w.WriteHeader(http.StatusBadRequest)
 		_, writerErr := w.Write([]byte("at least one of the following parameters must be provided: first_name, last_name"))
@@ -267,6 +551,8 @@ func (h *Handler) PortlandFuzzySearch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	officers = portlandFilterRanks(officers, ranks)
+
 	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(&officers)
@@ -274,3 +560,29 @@ func (h *Handler) PortlandFuzzySearch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// PortlandHeadcount is the handler function for retrieving PPB headcounts for each roster date
+func (h *Handler) PortlandHeadcount(w http.ResponseWriter, r *http.Request) {
+	h.headcount(w, r, []string{"title"}, h.db.PortlandHeadcountByDate)
+}
+
+// PortlandSalary is the handler function for retrieving PPB salary statistics
+func (h *Handler) PortlandSalary(w http.ResponseWriter, r *http.Request) {
+	h.salary(w, r, []string{"title"}, h.db.PortlandSalaryStats)
+}
+
+// portlandFilterRanks drops officers whose canonical rank was not requested
+func portlandFilterRanks(officers []*data.PortlandOfficer, ranks []string) []*data.PortlandOfficer {
+	filtered := []*data.PortlandOfficer{}
+	for _, officer := range officers {
+		if matchesRanks(ranks, officer.CanonicalRank) {
+			filtered = append(filtered, officer)
+		}
+	}
+	return filtered
+}
+
+// PortlandAutocomplete is the handler function for suggesting PPB officer names as they're typed
+func (h *Handler) PortlandAutocomplete(w http.ResponseWriter, r *http.Request) {
+	h.autocomplete(w, r, h.db.PortlandAutocomplete)
+}
@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/OrcaCollective/spd-lookup/api/data"
+)
+
+// freeTextBadge matches a free text query made of a badge number alone, e.g. "#6248"
+var freeTextBadge = regexp.MustCompile(`^#?\s*(\d+[A-Za-z]?)$`)
+
+// freeTextTitles maps the titles and abbreviations commonly written before a name onto
+// the canonical rank they stand for.
+var freeTextTitles = map[string]string{
+	"officer":    data.RankOfficer,
+	"ofc":        data.RankOfficer,
+	"po":         data.RankOfficer,
+	"deputy":     data.RankOfficer,
+	"dep":        data.RankOfficer,
+	"trooper":    data.RankOfficer,
+	"detective":  data.RankDetective,
+	"det":        data.RankDetective,
+	"sergeant":   data.RankSergeant,
+	"sgt":        data.RankSergeant,
+	"lieutenant": data.RankLieutenant,
+	"lieut":      data.RankLieutenant,
+	"lt":         data.RankLieutenant,
+	"captain":    data.RankCaptain,
+	"capt":       data.RankCaptain,
+	"cpt":        data.RankCaptain,
+	"commander":  data.RankCommand,
+	"cmdr":       data.RankCommand,
+	"chief":      data.RankCommand,
+	"major":      data.RankCommand,
+	"maj":        data.RankCommand,
+}
+
+// freeTextParticles lists the words that belong to the last name they precede, e.g. "de la Cruz"
+var freeTextParticles = map[string]bool{
+	"da": true, "de": true, "del": true, "della": true, "di": true, "du": true,
+	"la": true, "le": true, "st": true, "van": true, "von": true, "der": true,
+}
+
+// freeTextQuery holds the components parsed out of a free text query
+type freeTextQuery struct {
+	badge      string
+	firstName  string
+	middleName string
+	lastName   string
+	rank       string
+}
+
+// parseFreeText splits a free text query such as "Diaz, Adrian", "Adrian Z Diaz", "#6248"
+// or "Sgt Kelly" into a badge, names and the canonical rank of a leading title. A single
+// name is taken to be a last name.
+func parseFreeText(q string) freeTextQuery {
+	parsed := freeTextQuery{}
+	q = strings.TrimSpace(q)
+
+	if match := freeTextBadge.FindStringSubmatch(q); match != nil {
+		parsed.badge = match[1]
+		return parsed
+	}
+
+	// a comma separates a leading last name from the given names
+	var lastName []string
+	if comma := strings.Index(q, ","); comma >= 0 {
+		lastName = strings.Fields(q[:comma])
+		q = q[comma+1:]
+	}
+
+	words := []string{}
+	for _, word := range strings.Fields(q) {
+		word = strings.Trim(word, ".,")
+		if word == "" {
+			continue
+		}
+		if strings.HasPrefix(word, "#") && parsed.badge == "" {
+			parsed.badge = strings.TrimPrefix(word, "#")
+			continue
+		}
+		if rank, ok := freeTextTitles[strings.ToLower(word)]; ok && len(words) == 0 && parsed.rank == "" {
+			parsed.rank = rank
+			continue
+		}
+		words = append(words, word)
+	}
+
+	if len(lastName) == 0 {
+		// the last name starts at its particles, e.g. "Maria de la Cruz"
+		start := len(words) - 1
+		for start > 0 && freeTextParticles[strings.ToLower(words[start-1])] {
+			start--
+		}
+		if start < 0 {
+			return parsed
+		}
+		lastName, words = words[start:], words[:start]
+	}
+
+	parsed.lastName = strings.Join(lastName, " ")
+	if len(words) > 0 {
+		parsed.firstName = words[0]
+		parsed.middleName = strings.Join(words[1:], " ")
+	}
+	return parsed
+}
+
+// FreeTextQuery is middleware expanding the free text q parameter into the badge, name and
+// rank parameters understood by the officer routes. Parameters provided explicitly take
+// precedence over those parsed from q.
+func FreeTextQuery(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		q := strings.TrimSpace(query.Get("q"))
+		if q == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		parsed := parseFreeText(q)
+		for param, value := range map[string]string{
+			"badge":       parsed.badge,
+			"first_name":  parsed.firstName,
+			"middle_name": parsed.middleName,
+			"last_name":   parsed.lastName,
+			"rank":        parsed.rank,
+		} {
+			if value != "" && query.Get(param) == "" {
+				query.Set(param, value)
+			}
+		}
+		r.URL.RawQuery = query.Encode()
+
+		next.ServeHTTP(w, r)
+	})
+}
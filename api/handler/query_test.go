@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/OrcaCollective/spd-lookup/api/data"
+)
+
+func TestParseFreeText(t *testing.T) {
+	for _, tt := range []struct {
+		q      string
+		parsed freeTextQuery
+	}{
+		{"", freeTextQuery{}},
+		{"#6248", freeTextQuery{badge: "6248"}},
+		{"6248", freeTextQuery{badge: "6248"}},
+		{"Diaz", freeTextQuery{lastName: "Diaz"}},
+		{"Adrian Diaz", freeTextQuery{firstName: "Adrian", lastName: "Diaz"}},
+		{"Adrian Z. Diaz", freeTextQuery{firstName: "Adrian", middleName: "Z", lastName: "Diaz"}},
+		{"Diaz, Adrian", freeTextQuery{firstName: "Adrian", lastName: "Diaz"}},
+		{"Diaz, Adrian Z", freeTextQuery{firstName: "Adrian", middleName: "Z", lastName: "Diaz"}},
+		{"Sgt Kelly", freeTextQuery{lastName: "Kelly", rank: data.RankSergeant}},
+		{"Deputy John Smith", freeTextQuery{firstName: "John", lastName: "Smith", rank: data.RankOfficer}},
+		{"Officer Smith #1234", freeTextQuery{badge: "1234", lastName: "Smith", rank: data.RankOfficer}},
+		{"Maria de la Cruz", freeTextQuery{firstName: "Maria", lastName: "de la Cruz"}},
+		{"de la Cruz", freeTextQuery{lastName: "de la Cruz"}},
+		{"Van Dyke", freeTextQuery{lastName: "Van Dyke"}},
+		{"Sgt", freeTextQuery{rank: data.RankSergeant}},
+	} {
+		t.Run(tt.q, func(t *testing.T) {
+			if parsed := parseFreeText(tt.q); parsed != tt.parsed {
+				t.Errorf("parseFreeText(%q) = %+v, want %+v", tt.q, parsed, tt.parsed)
+			}
+		})
+	}
+}
+
+func TestFreeTextQuery(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		query string
+		want  url.Values
+	}{
+		{"NoQuery", "first_name=Adrian", url.Values{"first_name": {"Adrian"}}},
+		{"Parsed", "q=Sgt+Adrian+Diaz", url.Values{"q": {"Sgt Adrian Diaz"}, "first_name": {"Adrian"}, "last_name": {"Diaz"}, "rank": {data.RankSergeant}}},
+		{"ExplicitTakesPrecedence", "q=Adrian+Diaz&last_name=Kelly", url.Values{"q": {"Adrian Diaz"}, "first_name": {"Adrian"}, "last_name": {"Kelly"}}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var got url.Values
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.URL.Query()
+			})
+			FreeTextQuery(next).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/seattle/officer?"+tt.query, nil))
+
+			if got.Encode() != tt.want.Encode() {
+				t.Errorf("query = %s, want %s", got.Encode(), tt.want.Encode())
+			}
+		})
+	}
+}
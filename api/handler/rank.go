@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/OrcaCollective/spd-lookup/api/data"
+)
+
+// parseRanks reads the comma separated canonical ranks provided through the rank parameter.
+// An empty list is returned when no rank filter was requested.
+func parseRanks(r *http.Request) ([]string, error) {
+	ranks := []string{}
+	for _, rank := range strings.Split(r.URL.Query().Get("rank"), ",") {
+		rank = strings.ToLower(strings.TrimSpace(rank))
+		if rank == "" {
+			continue
+		}
+		if !contains(data.CanonicalRanks, rank) {
+			return nil, fmt.Errorf("rank must be one of the following: %s", strings.Join(data.CanonicalRanks, ", "))
+		}
+		ranks = append(ranks, rank)
+	}
+	return ranks, nil
+}
+
+// matchesRanks reports whether an officer's canonical rank passes the requested rank filter
+func matchesRanks(ranks []string, rank string) bool {
+	return len(ranks) == 0 || contains(ranks, rank)
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"fmt"
+	"regexp/syntax"
+)
+
+// Limits on the regular expressions accepted by strict searches, keeping them cheap for
+// Postgres to evaluate against every roster entry.
+const (
+	regexMaxLength     = 100
+	regexMaxNodes      = 50
+	regexMaxRepetition = 20
+)
+
+// validateRegex checks that a regular expression provided through param parses and stays
+// within the complexity limits. Empty expressions are valid and match everything.
+func validateRegex(param, expression string) error {
+	if expression == "" {
+		return nil
+	}
+	if len(expression) > regexMaxLength {
+		return fmt.Errorf("%s must be a regular expression of at most %d characters", param, regexMaxLength)
+	}
+
+	re, err := syntax.Parse(expression, syntax.Perl)
+	if err != nil {
+		return fmt.Errorf("%s must be a valid regular expression: %s", param, err)
+	}
+
+	nodes := 0
+	if !withinRegexLimits(re, false, &nodes) {
+		return fmt.Errorf("%s is too complex a regular expression: nested repetition, repetition counts above %d and more than %d terms are not supported", param, regexMaxRepetition, regexMaxNodes)
+	}
+	return nil
+}
+
+// withinRegexLimits walks a parsed regular expression, rejecting repetition nested within
+// repetition, large repetition counts and expressions with too many terms.
+func withinRegexLimits(re *syntax.Regexp, repeated bool, nodes *int) bool {
+	*nodes++
+	if *nodes > regexMaxNodes {
+		return false
+	}
+
+	switch re.Op {
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		if repeated && re.Op != syntax.OpQuest {
+			return false
+		}
+		if re.Op == syntax.OpRepeat && (re.Min > regexMaxRepetition || re.Max > regexMaxRepetition) {
+			return false
+		}
+		repeated = repeated || re.Op != syntax.OpQuest
+	}
+
+	for _, sub := range re.Sub {
+		if !withinRegexLimits(sub, repeated, nodes) {
+			return false
+		}
+	}
+	return true
+}
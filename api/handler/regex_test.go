@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateRegex(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		expression string
+		err        string
+	}{
+		{name: "Empty", expression: ""},
+		{name: "Literal", expression: "Smith"},
+		{name: "Alternation", expression: "^(Smith|Smyth)$"},
+		{name: "Repetition", expression: "^Sm.{1,3}th$"},
+		{name: "OptionalWithinRepetition", expression: "(ab?)+"},
+		{name: "TooLong", expression: strings.Repeat("a", regexMaxLength+1), err: "last_name must be a regular expression of at most 100 characters"},
+		{name: "Invalid", expression: "Sm(ith", err: "last_name must be a valid regular expression: error parsing regexp: missing closing ): `Sm(ith`"},
+		{name: "NestedRepetition", expression: "(a+)+", err: "last_name is too complex a regular expression: nested repetition, repetition counts above 20 and more than 50 terms are not supported"},
+		{name: "LargeRepetition", expression: "a{21}", err: "last_name is too complex a regular expression: nested repetition, repetition counts above 20 and more than 50 terms are not supported"},
+		{name: "TooManyTerms", expression: strings.Repeat("(a)", 30), err: "last_name is too complex a regular expression: nested repetition, repetition counts above 20 and more than 50 terms are not supported"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRegex("last_name", tt.expression)
+			if tt.err == "" {
+				if err != nil {
+					t.Errorf("validateRegex(%q) = %v, want nil", tt.expression, err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("validateRegex(%q) = %v, want %q", tt.expression, err, tt.err)
+			}
+		})
+	}
+}
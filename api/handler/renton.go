@@ -21,34 +21,91 @@ func (h *Handler) RentonOfficerMetadata(w http.ResponseWriter, r *http.Request)
 
 // RentonStrictMatch is the handler function for retrieving Renton officers with a strict match
 func (h *Handler) RentonStrictMatch(w http.ResponseWriter, r *http.Request) {
-	firstName, lastName := r.URL.Query().Get("first_name"), r.URL.Query().Get("last_name")
+	badge, firstName, lastName := r.URL.Query().Get("badge"), r.URL.Query().Get("first_name"), r.URL.Query().Get("last_name")
+	middleName, fullName := r.URL.Query().Get("middle_name"), r.URL.Query().Get("full_name")
 
-	if firstName != "" || lastName != "" {
-		h.rentonGetOfficersByName(firstName, lastName, w)
+	ranks, err := parseRanks(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	filters, err := parseFilters(r, "title", "department", "division", "shift", "middle_name", "full_name")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	badgeSearch, err := parseBadgeSearch(r, badge)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	nicknames, err := parseNicknames(r, firstName)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	if badge != "" {
+		h.rentonGetOfficersByBadge(badgeSearch, ranks, w)
+		return
+	} else if firstName != "" || lastName != "" || middleName != "" || fullName != "" {
+		h.rentonGetOfficersByName(firstName, lastName, nicknames, filters, ranks, w)
 		return
 	} else {
 		w.WriteHeader(http.StatusBadRequest)
-		_, err := w.Write([]byte("at least one of the following parameters must be provided: first_name, last_name"))
+		_, err := w.Write([]byte("at least one of the following parameters must be provided: badge, first_name, middle_name, last_name, full_name"))
 		if err != nil {
 			return
 		}
 	}
 }
 
-func (h *Handler) rentonGetOfficersByName(firstName, lastName string, w http.ResponseWriter) {
-	if firstName == "" {
-		firstName = "%"
-	} else {
-		firstName = strings.ReplaceAll(firstName, "*", "%")
+func (h *Handler) rentonGetOfficersByBadge(badge data.BadgeSearch, ranks []string, w http.ResponseWriter) {
+	officers, err := h.db.RentonSearchOfficerByBadge(badge)
+
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, errWrite := w.Write([]byte(fmt.Sprintf("error getting officer: %s", err)))
+		if errWrite != nil {
+			return
+		}
+		return
 	}
 
-	if lastName == "" {
-		lastName = "%"
-	} else {
-		lastName = strings.ReplaceAll(lastName, "*", "%")
+	officers = rentonFilterRanks(officers, ranks)
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(&officers)
+	if err != nil {
+		return
 	}
+}
+
+func (h *Handler) rentonGetOfficersByName(firstName, lastName string, nicknames []string, filters data.Filters, ranks []string, w http.ResponseWriter) {
+	firstName = namePattern(firstName, filters)
+	lastName = namePattern(lastName, filters)
 
-	officers, err := h.db.RentonSearchOfficerByName(firstName, lastName)
+	officers, err := h.db.RentonSearchOfficerByName(firstName, lastName, nicknames, filters)
 
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -59,6 +116,8 @@ func (h *Handler) rentonGetOfficersByName(firstName, lastName string, w http.Res
 		return
 	}
 
+	officers = rentonFilterRanks(officers, ranks)
+
 	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(&officers)
@@ -70,19 +129,56 @@ func (h *Handler) rentonGetOfficersByName(firstName, lastName string, w http.Res
 // RentonFuzzySearch is the handler function for retrieving APD officers through fuzzy search
 func (h *Handler) RentonFuzzySearch(w http.ResponseWriter, r *http.Request) {
 	firstName, lastName := strings.TrimSpace(r.URL.Query().Get("first_name")), strings.TrimSpace(r.URL.Query().Get("last_name"))
+	middleName, fullName := strings.TrimSpace(r.URL.Query().Get("middle_name")), strings.TrimSpace(r.URL.Query().Get("full_name"))
 
 	officers := []*data.RentonOfficer{}
 	var err error
 
-	if firstName != "" && lastName != "" {
-		officers, err = h.db.RentonFuzzySearchByName(strings.Trim(firstName+" "+lastName, " "))
+	ranks, err := parseRanks(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	opts, err := parseFuzzyOptions(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	opts.Nicknames, err = parseNicknames(r, firstName)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	if middleName != "" {
+		opts.MiddleName = globToLike(middleName)
+	}
+
+	if fullName != "" {
+		officers, err = h.db.RentonFuzzySearchByFullName(fullName, opts)
+	} else if firstName != "" && lastName != "" {
+		officers, err = h.db.RentonFuzzySearchByName(strings.Trim(firstName+" "+lastName, " "), opts)
 	} else if firstName != "" {
-		officers, err = h.db.RentonFuzzySearchByFirstName(firstName)
+		officers, err = h.db.RentonFuzzySearchByFirstName(firstName, opts)
 	} else if lastName != "" {
-		officers, err = h.db.RentonFuzzySearchByLastName(lastName)
+		officers, err = h.db.RentonFuzzySearchByLastName(lastName, opts)
 	} else {
 		w.WriteHeader(http.StatusBadRequest)
-		_, writerErr := w.Write([]byte("at least one of the following parameters must be provided: first_name, last_name"))
+		_, writerErr := w.Write([]byte("at least one of the following parameters must be provided: first_name, last_name, full_name"))
 		if writerErr != nil {
 			return
 		}
@@ -98,6 +194,8 @@ func (h *Handler) RentonFuzzySearch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	officers = rentonFilterRanks(officers, ranks)
+
 	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(&officers)
@@ -105,3 +203,19 @@ func (h *Handler) RentonFuzzySearch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// rentonFilterRanks drops officers whose canonical rank was not requested
+func rentonFilterRanks(officers []*data.RentonOfficer, ranks []string) []*data.RentonOfficer {
+	filtered := []*data.RentonOfficer{}
+	for _, officer := range officers {
+		if matchesRanks(ranks, officer.CanonicalRank) {
+			filtered = append(filtered, officer)
+		}
+	}
+	return filtered
+}
+
+// RentonAutocomplete is the handler function for suggesting RPD officer names as they're typed
+func (h *Handler) RentonAutocomplete(w http.ResponseWriter, r *http.Request) {
+	h.autocomplete(w, r, h.db.RentonAutocomplete)
+}
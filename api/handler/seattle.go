@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/OrcaCollective/spd-lookup/api/data"
 )
@@ -23,16 +24,57 @@ func (h *Handler) SeattleOfficerMetadata(w http.ResponseWriter, r *http.Request)
 // SeattleStrictMatch is the handler function for retrieving SPD officers with a strict match
 func (h *Handler) SeattleStrictMatch(w http.ResponseWriter, r *http.Request) {
 	badge, firstName, lastName := r.URL.Query().Get("badge"), r.URL.Query().Get("first_name"), r.URL.Query().Get("last_name")
+	middleName, fullName := r.URL.Query().Get("middle_name"), r.URL.Query().Get("full_name")
+
+	ranks, err := parseRanks(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	filters, err := parseFilters(r, "title", "unit", "unit_description", "middle_name", "full_name")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	badgeSearch, err := parseBadgeSearch(r, badge)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	nicknames, err := parseNicknames(r, firstName)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
 
 	if badge != "" {
-		h.seattleGetOfficerByBadge(badge, w)
+		h.seattleGetOfficerByBadge(badgeSearch, ranks, w)
 		return
-	} else if firstName != "" || lastName != "" {
-		h.seattleGetOfficersByName(firstName, lastName, w)
+	} else if firstName != "" || lastName != "" || middleName != "" || fullName != "" {
+		h.seattleGetOfficersByName(firstName, lastName, nicknames, filters, ranks, w)
 		return
 	} else {
 		w.WriteHeader(http.StatusBadRequest)
-		_, err := w.Write([]byte("at least one of the following parameters must be provided: badge, first_name, last_name"))
+		_, err := w.Write([]byte("at least one of the following parameters must be provided: badge, first_name, middle_name, last_name, full_name"))
 		if err != nil {
 			return
 		}
@@ -43,8 +85,18 @@ func (h *Handler) SeattleStrictMatch(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) SeattleStrictMatchHistorical(w http.ResponseWriter, r *http.Request) {
 	badge := r.URL.Query().Get("badge")
 
+	ranks, err := parseRanks(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
 	if badge != "" {
-		h.seattleGetOfficerByBadgeHistorical(badge, w)
+		h.seattleGetOfficerByBadgeHistorical(badge, ranks, w)
 		return
 	} else {
 		w.WriteHeader(http.StatusBadRequest)
@@ -55,7 +107,7 @@ func (h *Handler) SeattleStrictMatchHistorical(w http.ResponseWriter, r *http.Re
 	}
 }
 
-func (h *Handler) seattleGetOfficerByBadge(badge string, w http.ResponseWriter) {
+func (h *Handler) seattleGetOfficerByBadge(badge data.BadgeSearch, ranks []string, w http.ResponseWriter) {
 	officers, err := h.db.SeattleGetOfficerByBadge(badge)
 
 	if err != nil {
@@ -67,12 +119,17 @@ func (h *Handler) seattleGetOfficerByBadge(badge string, w http.ResponseWriter)
 		return
 	}
 
-	sort.Slice(officers, func(a, b int) bool {
-		if officers[a].LastName == officers[b].LastName {
-			return officers[a].FirstName < officers[b].FirstName
-		}
-		return officers[a].LastName < officers[b].LastName
-	})
+	// matches found by partial or confusable badges keep their ranking
+	if badge.Mode == data.BadgeModeExact {
+		sort.Slice(officers, func(a, b int) bool {
+			if officers[a].LastName == officers[b].LastName {
+				return officers[a].FirstName < officers[b].FirstName
+			}
+			return officers[a].LastName < officers[b].LastName
+		})
+	}
+
+	officers = seattleFilterRanks(officers, ranks)
 
 	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
@@ -82,20 +139,11 @@ func (h *Handler) seattleGetOfficerByBadge(badge string, w http.ResponseWriter)
 	}
 }
 
-func (h *Handler) seattleGetOfficersByName(firstName, lastName string, w http.ResponseWriter) {
-	if firstName == "" {
-		firstName = "%"
-	} else {
-		firstName = strings.ReplaceAll(firstName, "*", "%")
-	}
-
-	if lastName == "" {
-		lastName = "%"
-	} else {
-		lastName = strings.ReplaceAll(lastName, "*", "%")
-	}
+func (h *Handler) seattleGetOfficersByName(firstName, lastName string, nicknames []string, filters data.Filters, ranks []string, w http.ResponseWriter) {
+	firstName = namePattern(firstName, filters)
+	lastName = namePattern(lastName, filters)
 
-	officers, err := h.db.SeattleSearchOfficerByName(firstName, lastName)
+	officers, err := h.db.SeattleSearchOfficerByName(firstName, lastName, nicknames, filters)
 
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -106,6 +154,8 @@ func (h *Handler) seattleGetOfficersByName(firstName, lastName string, w http.Re
 		return
 	}
 
+	officers = seattleFilterRanks(officers, ranks)
+
 	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(&officers)
@@ -114,7 +164,7 @@ func (h *Handler) seattleGetOfficersByName(firstName, lastName string, w http.Re
 	}
 }
 
-func (h *Handler) seattleGetOfficerByBadgeHistorical(badge string, w http.ResponseWriter) {
+func (h *Handler) seattleGetOfficerByBadgeHistorical(badge string, ranks []string, w http.ResponseWriter) {
 	officers, err := h.db.SeattleGetOfficerByBadgeHistorical(badge)
 
 	if err != nil {
@@ -126,6 +176,8 @@ func (h *Handler) seattleGetOfficerByBadgeHistorical(badge string, w http.Respon
 		return
 	}
 
+	officers = seattleFilterRanks(officers, ranks)
+
 	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(&officers)
@@ -137,19 +189,56 @@ func (h *Handler) seattleGetOfficerByBadgeHistorical(badge string, w http.Respon
 // SeattleFuzzySearch is the handler function for retrieving SPD officers through fuzzy search
 func (h *Handler) SeattleFuzzySearch(w http.ResponseWriter, r *http.Request) {
 	firstName, lastName := strings.TrimSpace(r.URL.Query().Get("first_name")), strings.TrimSpace(r.URL.Query().Get("last_name"))
+	middleName, fullName := strings.TrimSpace(r.URL.Query().Get("middle_name")), strings.TrimSpace(r.URL.Query().Get("full_name"))
 
 	officers := []*data.SeattleOfficer{}
 	var err error
 
-	if firstName != "" && lastName != "" {
-		officers, err = h.db.SeattleFuzzySearchByName(strings.Trim(firstName+" "+lastName, " "))
+	ranks, err := parseRanks(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	opts, err := parseFuzzyOptions(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	opts.Nicknames, err = parseNicknames(r, firstName)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	if middleName != "" {
+		opts.MiddleName = globToLike(middleName)
+	}
+
+	if fullName != "" {
+		officers, err = h.db.SeattleFuzzySearchByFullName(fullName, opts)
+	} else if firstName != "" && lastName != "" {
+		officers, err = h.db.SeattleFuzzySearchByName(strings.Trim(firstName+" "+lastName, " "), opts)
 	} else if firstName != "" {
-		officers, err = h.db.SeattleFuzzySearchByFirstName(firstName)
+		officers, err = h.db.SeattleFuzzySearchByFirstName(firstName, opts)
 	} else if lastName != "" {
-		officers, err = h.db.SeattleFuzzySearchByLastName(lastName)
+		officers, err = h.db.SeattleFuzzySearchByLastName(lastName, opts)
 	} else {
 		w.WriteHeader(http.StatusBadRequest)
-		_, writerErr := w.Write([]byte("at least one of the following parameters must be provided: first_name, last_name"))
+		_, writerErr := w.Write([]byte("at least one of the following parameters must be provided: first_name, last_name, full_name"))
 		if writerErr != nil {
 			return
 		}
@@ -165,6 +254,8 @@ func (h *Handler) SeattleFuzzySearch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	officers = seattleFilterRanks(officers, ranks)
+
 	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(&officers)
@@ -172,3 +263,85 @@ func (h *Handler) SeattleFuzzySearch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// SeattlePrecincts is the handler function for retrieving SPD precincts as GeoJSON
+func (h *Handler) SeattlePrecincts(w http.ResponseWriter, r *http.Request) {
+	precincts, err := h.db.SeattlePrecincts()
+
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, writeErr := w.Write([]byte(fmt.Sprintf("error getting precincts: %s", err)))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/geo+json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(precincts)
+	if err != nil {
+		return
+	}
+}
+
+// SeattleHeadcount is the handler function for retrieving SPD headcounts for each roster date
+func (h *Handler) SeattleHeadcount(w http.ResponseWriter, r *http.Request) {
+	h.headcount(w, r, []string{"title", "unit"}, h.db.SeattleHeadcountByDate)
+}
+
+// SeattlePromotionsReport is the handler function for retrieving SPD officers whose rank
+// changed between the from and to dates. Both dates are optional and inclusive.
+func (h *Handler) SeattlePromotionsReport(w http.ResponseWriter, r *http.Request) {
+	from, to := time.Time{}, time.Now()
+	var err error
+
+	if fromParam := strings.TrimSpace(r.URL.Query().Get("from")); fromParam != "" {
+		from, err = time.Parse("2006-01-02", fromParam)
+	}
+	if toParam := strings.TrimSpace(r.URL.Query().Get("to")); err == nil && toParam != "" {
+		to, err = time.Parse("2006-01-02", toParam)
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte("from and to must be dates in the format YYYY-MM-DD"))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	changes, err := h.db.SeattleTitleChanges(from, to)
+
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, writeErr := w.Write([]byte(fmt.Sprintf("error getting title changes: %s", err)))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(&changes)
+	if err != nil {
+		return
+	}
+}
+
+// seattleFilterRanks drops officers whose canonical rank was not requested
+func seattleFilterRanks(officers []*data.SeattleOfficer, ranks []string) []*data.SeattleOfficer {
+	filtered := []*data.SeattleOfficer{}
+	for _, officer := range officers {
+		if matchesRanks(ranks, officer.CanonicalRank) {
+			filtered = append(filtered, officer)
+		}
+	}
+	return filtered
+}
+
+// SeattleAutocomplete is the handler function for suggesting SPD officer names as they're typed
+func (h *Handler) SeattleAutocomplete(w http.ResponseWriter, r *http.Request) {
+	h.autocomplete(w, r, h.db.SeattleAutocomplete)
+}
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/OrcaCollective/spd-lookup/api/data"
+)
+
+// headcount writes the roster headcount time series returned by headcountByDate, validating
+// the group_by parameter against the groups supported by the department.
+func (h *Handler) headcount(w http.ResponseWriter, r *http.Request, groups []string, headcountByDate func(groupBy string) ([]*data.HeadcountStat, error)) {
+	groupBy := strings.TrimSpace(r.URL.Query().Get("group_by"))
+
+	if groupBy != "" && !contains(groups, groupBy) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err := w.Write([]byte(fmt.Sprintf("group_by must be one of the following: %s", strings.Join(groups, ", "))))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	stats, err := headcountByDate(groupBy)
+
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, writeErr := w.Write([]byte(fmt.Sprintf("error getting headcount: %s", err)))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(&stats)
+	if err != nil {
+		return
+	}
+}
+
+// salary writes the salary statistics returned by salaryStats, validating the group_by
+// parameter against the groups supported by the department.
+func (h *Handler) salary(w http.ResponseWriter, r *http.Request, groups []string, salaryStats func(groupBy string) ([]*data.SalaryStat, error)) {
+	groupBy := strings.TrimSpace(r.URL.Query().Get("group_by"))
+
+	if groupBy != "" && !contains(groups, groupBy) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err := w.Write([]byte(fmt.Sprintf("group_by must be one of the following: %s", strings.Join(groups, ", "))))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	stats, err := salaryStats(groupBy)
+
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, writeErr := w.Write([]byte(fmt.Sprintf("error getting salaries: %s", err)))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(&stats)
+	if err != nil {
+		return
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -24,6 +24,46 @@ func (h *Handler) TacomaOfficerMetadata(w http.ResponseWriter, r *http.Request)
 func (h *Handler) TacomaStrictMatch(w http.ResponseWriter, r *http.Request) {
 	badge, firstName, lastName := r.URL.Query().Get("badge"), strings.TrimSpace(r.URL.Query().Get("first_name")), strings.TrimSpace(r.URL.Query().Get("last_name"))
 
+	ranks, err := parseRanks(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	filters, err := parseFilters(r, "title")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	filters, err = parseRanges(r, filters, "salary")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	nicknames, err := parseNicknames(r, firstName)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
 	if badge != "" && firstName == "" && lastName == "" {
 		w.WriteHeader(http.StatusBadRequest)
 		_, writerErr := w.Write([]byte("At this time we do not have the badge numbers available for Tacoma PD. Please attempt searches by first or last name only."))
@@ -33,29 +73,24 @@ func (h *Handler) TacomaStrictMatch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if firstName == "" && lastName == "" {
+	if firstName == "" && lastName == "" && len(filters.Ranges) == 0 {
 		w.WriteHeader(http.StatusBadRequest)
-		_, writerErr := w.Write([]byte("at least one of the following parameters must be provided: first_name, last_name"))
+		_, writerErr := w.Write([]byte("at least one of the following parameters must be provided: first_name, last_name, salary_min, salary_max"))
 		if writerErr != nil {
 			return
 		}
 		return
 	}
 
-	if firstName == "" {
-		firstName = "%"
-	} else {
-		firstName = strings.ReplaceAll(firstName, "*", "%")
-	}
-
-	if lastName == "" {
-		lastName = "%"
+	var officers []*data.TacomaOfficer
+	if firstName == "" && lastName == "" {
+		officers, err = h.db.TacomaSearchOfficersByFilters(filters)
 	} else {
-		lastName = strings.ReplaceAll(lastName, "*", "%")
+		firstName = namePattern(firstName, filters)
+		lastName = namePattern(lastName, filters)
+		officers, err = h.db.TacomaSearchOfficerByName(firstName, lastName, nicknames, filters)
 	}
 
-	officers, err := h.db.TacomaSearchOfficerByName(firstName, lastName)
-
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, writerErr := w.Write([]byte(fmt.Sprintf("error getting officer: %s", err)))
@@ -72,6 +107,8 @@ func (h *Handler) TacomaStrictMatch(w http.ResponseWriter, r *http.Request) {
 		return officers[a].LastName < officers[b].LastName
 	})
 
+	officers = tacomaFilterRanks(officers, ranks)
+
 	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(&officers)
@@ -87,12 +124,42 @@ func (h *Handler) TacomaFuzzySearch(w http.ResponseWriter, r *http.Request) {
 	officers := []*data.TacomaOfficer{}
 	var err error
 
+	ranks, err := parseRanks(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	opts, err := parseFuzzyOptions(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	opts.Nicknames, err = parseNicknames(r, firstName)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
 	if firstName != "" && lastName != "" {
-		officers, err = h.db.TacomaFuzzySearchByName(strings.Trim(firstName+" "+lastName, " "))
+		officers, err = h.db.TacomaFuzzySearchByName(strings.Trim(firstName+" "+lastName, " "), opts)
 	} else if firstName != "" {
-		officers, err = h.db.TacomaFuzzySearchByFirstName(firstName)
+		officers, err = h.db.TacomaFuzzySearchByFirstName(firstName, opts)
 	} else if lastName != "" {
-		officers, err = h.db.TacomaFuzzySearchByLastName(lastName)
+		officers, err = h.db.TacomaFuzzySearchByLastName(lastName, opts)
 	} else {
 		w.WriteHeader(http.StatusBadRequest)
 		_, writerErr := w.Write([]byte("at least one of the following parameters must be provided: first_name, last_name"))
@@ -111,6 +178,8 @@ func (h *Handler) TacomaFuzzySearch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	officers = tacomaFilterRanks(officers, ranks)
+
 	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(&officers)
@@ -118,3 +187,29 @@ func (h *Handler) TacomaFuzzySearch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// TacomaHeadcount is the handler function for retrieving Tacoma PD headcounts for each roster date
+func (h *Handler) TacomaHeadcount(w http.ResponseWriter, r *http.Request) {
+	h.headcount(w, r, []string{"title"}, h.db.TacomaHeadcountByDate)
+}
+
+// TacomaSalary is the handler function for retrieving Tacoma PD salary statistics
+func (h *Handler) TacomaSalary(w http.ResponseWriter, r *http.Request) {
+	h.salary(w, r, []string{"title", "department"}, h.db.TacomaSalaryStats)
+}
+
+// tacomaFilterRanks drops officers whose canonical rank was not requested
+func tacomaFilterRanks(officers []*data.TacomaOfficer, ranks []string) []*data.TacomaOfficer {
+	filtered := []*data.TacomaOfficer{}
+	for _, officer := range officers {
+		if matchesRanks(ranks, officer.CanonicalRank) {
+			filtered = append(filtered, officer)
+		}
+	}
+	return filtered
+}
+
+// TacomaAutocomplete is the handler function for suggesting TPD officer names as they're typed
+func (h *Handler) TacomaAutocomplete(w http.ResponseWriter, r *http.Request) {
+	h.autocomplete(w, r, h.db.TacomaAutocomplete)
+}
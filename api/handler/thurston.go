@@ -23,8 +23,38 @@ func (h *Handler) ThurstonCountyOfficerMetadata(w http.ResponseWriter, r *http.R
 func (h *Handler) ThurstonCountyStrictMatch(w http.ResponseWriter, r *http.Request) {
 	firstName, lastName := r.URL.Query().Get("first_name"), r.URL.Query().Get("last_name")
 
+	ranks, err := parseRanks(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	filters, err := parseFilters(r, "title", "call_sign")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	nicknames, err := parseNicknames(r, firstName)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
 	if firstName != "" || lastName != "" {
-		h.thurstonCountyGetOfficersByName(firstName, lastName, w)
+		h.thurstonCountyGetOfficersByName(firstName, lastName, nicknames, filters, ranks, w)
 		return
 	} else {
 		w.WriteHeader(http.StatusBadRequest)
@@ -35,20 +65,11 @@ func (h *Handler) ThurstonCountyStrictMatch(w http.ResponseWriter, r *http.Reque
 	}
 }
 
-func (h *Handler) thurstonCountyGetOfficersByName(firstName, lastName string, w http.ResponseWriter) {
-	if firstName == "" {
-		firstName = "%"
-	} else {
-		firstName = strings.ReplaceAll(firstName, "*", "%")
-	}
+func (h *Handler) thurstonCountyGetOfficersByName(firstName, lastName string, nicknames []string, filters data.Filters, ranks []string, w http.ResponseWriter) {
+	firstName = namePattern(firstName, filters)
+	lastName = namePattern(lastName, filters)
 
-	if lastName == "" {
-		lastName = "%"
-	} else {
-		lastName = strings.ReplaceAll(lastName, "*", "%")
-	}
-
-	officers, err := h.db.ThurstonCountySearchOfficerByName(firstName, lastName)
+	officers, err := h.db.ThurstonCountySearchOfficerByName(firstName, lastName, nicknames, filters)
 
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -59,6 +80,8 @@ func (h *Handler) thurstonCountyGetOfficersByName(firstName, lastName string, w
 		return
 	}
 
+	officers = thurstonCountyFilterRanks(officers, ranks)
+
 	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(&officers)
@@ -74,12 +97,42 @@ func (h *Handler) ThurstonCountyFuzzySearch(w http.ResponseWriter, r *http.Reque
 	officers := []*data.ThurstonCountyOfficer{}
 	var err error
 
+	ranks, err := parseRanks(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	opts, err := parseFuzzyOptions(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
+	opts.Nicknames, err = parseNicknames(r, firstName)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, writeErr := w.Write([]byte(err.Error()))
+		if writeErr != nil {
+			return
+		}
+		return
+	}
+
 	if firstName != "" && lastName != "" {
-		officers, err = h.db.ThurstonCountyFuzzySearchByName(strings.Trim(firstName+" "+lastName, " "))
+		officers, err = h.db.ThurstonCountyFuzzySearchByName(strings.Trim(firstName+" "+lastName, " "), opts)
 	} else if firstName != "" {
-		officers, err = h.db.ThurstonCountyFuzzySearchByFirstName(firstName)
+		officers, err = h.db.ThurstonCountyFuzzySearchByFirstName(firstName, opts)
 	} else if lastName != "" {
-		officers, err = h.db.ThurstonCountyFuzzySearchByLastName(lastName)
+		officers, err = h.db.ThurstonCountyFuzzySearchByLastName(lastName, opts)
 	} else {
 		w.WriteHeader(http.StatusBadRequest)
 		_, writerErr := w.Write([]byte("at least one of the following parameters must be provided: first_name, last_name"))
@@ -98,6 +151,8 @@ func (h *Handler) ThurstonCountyFuzzySearch(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	officers = thurstonCountyFilterRanks(officers, ranks)
+
 	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(&officers)
@@ -105,3 +160,19 @@ func (h *Handler) ThurstonCountyFuzzySearch(w http.ResponseWriter, r *http.Reque
 		return
 	}
 }
+
+// thurstonCountyFilterRanks drops officers whose canonical rank was not requested
+func thurstonCountyFilterRanks(officers []*data.ThurstonCountyOfficer, ranks []string) []*data.ThurstonCountyOfficer {
+	filtered := []*data.ThurstonCountyOfficer{}
+	for _, officer := range officers {
+		if matchesRanks(ranks, officer.CanonicalRank) {
+			filtered = append(filtered, officer)
+		}
+	}
+	return filtered
+}
+
+// ThurstonCountyAutocomplete is the handler function for suggesting TCSO officer names as they're typed
+func (h *Handler) ThurstonCountyAutocomplete(w http.ResponseWriter, r *http.Request) {
+	h.autocomplete(w, r, h.db.ThurstonCountyAutocomplete)
+}
@@ -31,6 +31,11 @@ func Start() {
 	}
 }
 
+// freeText wraps the officer search handlers accepting the free text q parameter
+func freeText(f http.HandlerFunc) http.Handler {
+	return handler.FreeTextQuery(f)
+}
+
 // NewRouter is the router constructor
 func NewRouter(h handler.Interface) http.Handler {
 	router := mux.NewRouter()
@@ -38,44 +43,66 @@ func NewRouter(h handler.Interface) http.Handler {
 	router.HandleFunc("/departments", h.DescribeDepartments).Methods("GET")
 
 	router.HandleFunc("/seattle/metadata", h.SeattleOfficerMetadata).Methods("GET")
-	router.HandleFunc("/seattle/officer", h.SeattleStrictMatch).Methods("GET")
-	router.HandleFunc("/seattle/officer/search", h.SeattleFuzzySearch).Methods("GET")
+	router.Handle("/seattle/officer", freeText(h.SeattleStrictMatch)).Methods("GET")
+	router.Handle("/seattle/officer/search", freeText(h.SeattleFuzzySearch)).Methods("GET")
+	router.HandleFunc("/seattle/officer/autocomplete", h.SeattleAutocomplete).Methods("GET")
 	router.HandleFunc("/seattle/officer/historical", h.SeattleStrictMatchHistorical).Methods("GET")
+	router.HandleFunc("/seattle/precincts", h.SeattlePrecincts).Methods("GET")
+	router.HandleFunc("/seattle/stats/headcount", h.SeattleHeadcount).Methods("GET")
+	router.HandleFunc("/seattle/reports/promotions", h.SeattlePromotionsReport).Methods("GET")
 
 	router.HandleFunc("/tacoma/metadata", h.TacomaOfficerMetadata).Methods("GET")
-	router.HandleFunc("/tacoma/officer", h.TacomaStrictMatch).Methods("GET")
-	router.HandleFunc("/tacoma/officer/search", h.TacomaFuzzySearch).Methods("GET")
+	router.Handle("/tacoma/officer", freeText(h.TacomaStrictMatch)).Methods("GET")
+	router.Handle("/tacoma/officer/search", freeText(h.TacomaFuzzySearch)).Methods("GET")
+	router.HandleFunc("/tacoma/officer/autocomplete", h.TacomaAutocomplete).Methods("GET")
+	router.HandleFunc("/tacoma/stats/headcount", h.TacomaHeadcount).Methods("GET")
+	router.HandleFunc("/tacoma/stats/salary", h.TacomaSalary).Methods("GET")
 
 	router.HandleFunc("/portland/metadata", h.PortlandOfficerMetadata).Methods("GET")
-	router.HandleFunc("/portland/officer", h.PortlandStrictMatch).Methods("GET")
-	router.HandleFunc("/portland/officer/search", h.PortlandFuzzySearch).Methods("GET")
+	router.Handle("/portland/officer", freeText(h.PortlandStrictMatch)).Methods("GET")
+	router.Handle("/portland/officer/search", freeText(h.PortlandFuzzySearch)).Methods("GET")
+	router.HandleFunc("/portland/officer/autocomplete", h.PortlandAutocomplete).Methods("GET")
+	router.HandleFunc("/portland/officer/historical", h.PortlandStrictMatchHistorical).Methods("GET")
+	router.HandleFunc("/portland/officer/rrt", h.PortlandRRTMembers).Methods("GET")
+	router.HandleFunc("/portland/stats/headcount", h.PortlandHeadcount).Methods("GET")
+	router.HandleFunc("/portland/stats/salary", h.PortlandSalary).Methods("GET")
 
 	router.HandleFunc("/auburn/metadata", h.AuburnOfficerMetadata).Methods("GET")
-	router.HandleFunc("/auburn/officer", h.AuburnStrictMatch).Methods("GET")
-	router.HandleFunc("/auburn/officer/search", h.AuburnFuzzySearch).Methods("GET")
+	router.Handle("/auburn/officer", freeText(h.AuburnStrictMatch)).Methods("GET")
+	router.Handle("/auburn/officer/search", freeText(h.AuburnFuzzySearch)).Methods("GET")
+	router.HandleFunc("/auburn/officer/autocomplete", h.AuburnAutocomplete).Methods("GET")
+	router.HandleFunc("/auburn/stats/headcount", h.AuburnHeadcount).Methods("GET")
 
 	router.HandleFunc("/lakewood/metadata", h.LakewoodOfficerMetadata).Methods("GET")
-	router.HandleFunc("/lakewood/officer", h.LakewoodStrictMatch).Methods("GET")
-	router.HandleFunc("/lakewood/officer/search", h.LakewoodFuzzySearch).Methods("GET")
+	router.Handle("/lakewood/officer", freeText(h.LakewoodStrictMatch)).Methods("GET")
+	router.Handle("/lakewood/officer/search", freeText(h.LakewoodFuzzySearch)).Methods("GET")
+	router.HandleFunc("/lakewood/officer/autocomplete", h.LakewoodAutocomplete).Methods("GET")
+	router.HandleFunc("/lakewood/stats/headcount", h.LakewoodHeadcount).Methods("GET")
 
 	router.HandleFunc("/bellevue/metadata", h.BellevueOfficerMetadata).Methods("GET")
-	router.HandleFunc("/bellevue/officer", h.BellevueStrictMatch).Methods("GET")
-	router.HandleFunc("/bellevue/officer/search", h.BellevueFuzzySearch).Methods("GET")
+	router.Handle("/bellevue/officer", freeText(h.BellevueStrictMatch)).Methods("GET")
+	router.Handle("/bellevue/officer/search", freeText(h.BellevueFuzzySearch)).Methods("GET")
+	router.HandleFunc("/bellevue/officer/autocomplete", h.BellevueAutocomplete).Methods("GET")
 
 	router.HandleFunc("/port_of_seattle/metadata", h.PortOfSeattleOfficerMetadata).Methods("GET")
-	router.HandleFunc("/port_of_seattle/officer", h.PortOfSeattleStrictMatch).Methods("GET")
-	router.HandleFunc("/port_of_seattle/officer/search", h.PortOfSeattleFuzzySearch).Methods("GET")
+	router.Handle("/port_of_seattle/officer", freeText(h.PortOfSeattleStrictMatch)).Methods("GET")
+	router.Handle("/port_of_seattle/officer/search", freeText(h.PortOfSeattleFuzzySearch)).Methods("GET")
+	router.HandleFunc("/port_of_seattle/officer/autocomplete", h.PortOfSeattleAutocomplete).Methods("GET")
 
 	router.HandleFunc("/thurston_county/metadata", h.ThurstonCountyOfficerMetadata).Methods("GET")
-	router.HandleFunc("/thurston_county/officer", h.ThurstonCountyStrictMatch).Methods("GET")
-	router.HandleFunc("/thurston_county/officer/search", h.ThurstonCountyFuzzySearch).Methods("GET")
+	router.Handle("/thurston_county/officer", freeText(h.ThurstonCountyStrictMatch)).Methods("GET")
+	router.Handle("/thurston_county/officer/search", freeText(h.ThurstonCountyFuzzySearch)).Methods("GET")
+	router.HandleFunc("/thurston_county/officer/autocomplete", h.ThurstonCountyAutocomplete).Methods("GET")
 
 	router.HandleFunc("/renton/metadata", h.RentonOfficerMetadata).Methods("GET")
-	router.HandleFunc("/renton/officer", h.RentonStrictMatch).Methods("GET")
-	router.HandleFunc("/renton/officer/search", h.RentonFuzzySearch).Methods("GET")
+	router.Handle("/renton/officer", freeText(h.RentonStrictMatch)).Methods("GET")
+	router.Handle("/renton/officer/search", freeText(h.RentonFuzzySearch)).Methods("GET")
+	router.HandleFunc("/renton/officer/autocomplete", h.RentonAutocomplete).Methods("GET")
 
 	router.HandleFunc("/olympia/metadata", h.OlympiaOfficerMetadata).Methods("GET")
-	router.HandleFunc("/olympia/officer", h.OlympiaStrictMatch).Methods("GET")
-	router.HandleFunc("/olympia/officer/search", h.OlympiaFuzzySearch).Methods("GET")
+	router.Handle("/olympia/officer", freeText(h.OlympiaStrictMatch)).Methods("GET")
+	router.Handle("/olympia/officer/search", freeText(h.OlympiaFuzzySearch)).Methods("GET")
+	router.HandleFunc("/olympia/officer/autocomplete", h.OlympiaAutocomplete).Methods("GET")
+	router.HandleFunc("/olympia/stats/headcount", h.OlympiaHeadcount).Methods("GET")
 	return router
 }
@@ -1 +1,13 @@
 CREATE EXTENSION pg_trgm;
+
+-- parse_amount parses amounts such as "$123,456.78", dropping currency symbols and thousands
+-- separators. Values that aren't amounts are parsed as NULL.
+CREATE OR REPLACE FUNCTION parse_amount(value TEXT)
+    RETURNS NUMERIC AS $$
+    SELECT CASE
+        WHEN REGEXP_REPLACE(value, '[$,[:space:]]', '', 'g') ~ '^-?\d+(\.\d+)?$'
+            THEN REGEXP_REPLACE(value, '[$,[:space:]]', '', 'g')::NUMERIC
+    END;
+$$
+LANGUAGE SQL
+IMMUTABLE;
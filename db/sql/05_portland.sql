@@ -1,4 +1,75 @@
+-- The Portland roster records dates in several layouts, yes/no flags as text and salaries as
+-- formatted amounts. It is loaded as text into portland_officers_import, then parsed into the
+-- typed columns of portland_officers so dates can be sorted and compared. Salaries are kept
+-- as recorded alongside their parsed amounts.
+
+-- portland_parse_date parses dates recorded as YYYY-MM-DD, M/D/YYYY or M/D/YY. Anything
+-- else, including "N/A" and impossible dates, is stored as NULL.
+CREATE OR REPLACE FUNCTION portland_parse_date(value TEXT)
+    RETURNS DATE AS $$
+BEGIN
+    value := TRIM(value);
+    IF value ~ '^\d{4}-\d{1,2}-\d{1,2}$' THEN
+        RETURN TO_DATE(value, 'YYYY-MM-DD');
+    ELSIF value ~ '^\d{1,2}/\d{1,2}/\d{4}$' THEN
+        RETURN TO_DATE(value, 'MM/DD/YYYY');
+    ELSIF value ~ '^\d{1,2}/\d{1,2}/\d{2}$' THEN
+        RETURN TO_DATE(value, 'MM/DD/YY');
+    END IF;
+    RETURN NULL;
+EXCEPTION
+    WHEN datetime_field_overflow OR invalid_datetime_format THEN
+        RETURN NULL;
+END; $$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+-- portland_parse_flag parses yes/no flags such as "Y", "yes" or "N". Blank and unrecognized
+-- values are stored as NULL.
+CREATE OR REPLACE FUNCTION portland_parse_flag(value TEXT)
+    RETURNS BOOLEAN AS $$
+    SELECT CASE
+        WHEN LOWER(TRIM(value)) IN ('y', 'yes', 'true', 'x') THEN TRUE
+        WHEN LOWER(TRIM(value)) IN ('n', 'no', 'false') THEN FALSE
+    END;
+$$
+LANGUAGE SQL
+IMMUTABLE;
+
 CREATE TABLE IF NOT EXISTS portland_officers (
+    id                              SERIAL PRIMARY KEY,
+    first_name                      VARCHAR(100),
+    last_name                       VARCHAR(100),
+    gender                          VARCHAR(15),
+    officer_rank                    VARCHAR(50),
+    employee_id                     VARCHAR(20),
+    helmet_id                       VARCHAR(10),
+    helmet_id_three_digit           VARCHAR(10),
+    salary                          VARCHAR(50),
+    salary_amount                   NUMERIC(12, 2),
+    badge                           VARCHAR(20),
+    cops_photo_profile_link         VARCHAR(100),
+    cops_photo_has_photo            BOOLEAN,
+    retired_or_cert_revoked         BOOLEAN,
+    retired_or_cert_revoked_date    DATE,
+    hire_year                       INTEGER,
+    hire_date                       DATE,
+    state_cert_date                 DATE,
+    state_cert_level                VARCHAR(20),
+    rrt                             BOOLEAN,
+    rrt_2016                        BOOLEAN,
+    rrt_2018_niiya_email            BOOLEAN,
+    rrt_2018                        BOOLEAN,
+    rrt_2019                        BOOLEAN,
+    rrt_2020                        BOOLEAN,
+    sound_truck_training_2020       BOOLEAN,
+    instructed_for_dpsst            BOOLEAN,
+    instructed_for_less_lethal      BOOLEAN,
+    involved_in_ois_uof             BOOLEAN,
+    notes                           VARCHAR(1000)
+);
+
+CREATE TEMPORARY TABLE portland_officers_import (
     id                              SERIAL PRIMARY KEY,
     first_name                      VARCHAR(100),
     last_name                       VARCHAR(100),
@@ -34,7 +105,7 @@ CREATE TABLE IF NOT EXISTS portland_officers (
     notes                           VARCHAR(1000)
 );
 
-COPY portland_officers (
+COPY portland_officers_import (
     employed_3_12_21,
     employed_12_28_20,
     employed_10_01_20,
@@ -70,127 +141,134 @@ COPY portland_officers (
 )
 FROM '/tmp/portland.csv' DELIMITER ',' CSV HEADER;
 
---------------------------------------------------------------------------------
--- Strict search
---------------------------------------------------------------------------------
-
--- Badge
-CREATE OR REPLACE FUNCTION portland_search_officer_by_badge_p(badge VARCHAR(10))
-    RETURNS SETOF portland_officers AS $$
-BEGIN
-    RETURN QUERY SELECT *
-    FROM portland_officers o
-    WHERE o.badge = portland_search_officer_by_badge_p.badge;
-    RETURN;
-END; $$
-LANGUAGE 'plpgsql'
-SECURITY DEFINER
-SET search_path =public, pg_temp;
-
-
--- Employee ID
-CREATE OR REPLACE FUNCTION portland_search_officer_by_employee_p(employee_id VARCHAR(20))
-    RETURNS SETOF portland_officers AS $$
-BEGIN
-    RETURN QUERY SELECT *
-    FROM portland_officers o
-    WHERE o.employee_id = portland_search_officer_by_employee_p.employee_id;
-    RETURN;
-END; $$
-LANGUAGE 'plpgsql'
-SECURITY DEFINER
-SET search_path =public, pg_temp;
-
-
--- Helmet ID
-CREATE OR REPLACE FUNCTION portland_search_officer_by_helmet_p(helmet_id VARCHAR(10))
-    RETURNS SETOF portland_officers AS $$
-BEGIN
-    RETURN QUERY SELECT *
-    FROM portland_officers o
-    WHERE o.helmet_id = portland_search_officer_by_helmet_p.helmet_id;
-    RETURN;
-END; $$
-LANGUAGE 'plpgsql'
-SECURITY DEFINER
-SET search_path =public, pg_temp;
-
-
--- Helmet ID 3 digit
-CREATE OR REPLACE FUNCTION portland_search_officer_by_helmet_three_digit_p(helmet_id_three_digit VARCHAR(10))
-    RETURNS SETOF portland_officers AS $$
-BEGIN
-    RETURN QUERY SELECT *
-    FROM portland_officers o
-    WHERE o.helmet_id_three_digit = portland_search_officer_by_helmet_three_digit_p.helmet_id_three_digit;
-    RETURN;
-END; $$
-LANGUAGE 'plpgsql'
-SECURITY DEFINER
-SET search_path =public, pg_temp;
-
-
--- Name
-CREATE OR REPLACE FUNCTION portland_search_officer_by_name_p(
-    first_name  VARCHAR(100),
-    last_name   VARCHAR(100)
-    )
-    RETURNS SETOF portland_officers AS $$
-BEGIN
-    RETURN QUERY SELECT *
-    FROM portland_officers o
-    WHERE LOWER(o.first_name) LIKE LOWER(portland_search_officer_by_name_p.first_name)
-    AND LOWER(o.last_name) LIKE LOWER(portland_search_officer_by_name_p.last_name);
-    RETURN;
-END; $$
-LANGUAGE 'plpgsql'
-SECURITY DEFINER
-SET search_path =public, pg_temp;
+INSERT INTO portland_officers (
+    id,
+    retired_or_cert_revoked,
+    retired_or_cert_revoked_date,
+    hire_year,
+    hire_date,
+    state_cert_date,
+    state_cert_level,
+    employee_id,
+    helmet_id,
+    helmet_id_three_digit,
+    officer_rank,
+    first_name,
+    last_name,
+    gender,
+    badge,
+    cops_photo_has_photo,
+    rrt,
+    rrt_2016,
+    rrt_2018_niiya_email,
+    rrt_2018,
+    rrt_2019,
+    rrt_2020,
+    sound_truck_training_2020,
+    instructed_for_dpsst,
+    instructed_for_less_lethal,
+    cops_photo_profile_link,
+    involved_in_ois_uof,
+    notes,
+    salary,
+    salary_amount
+)
+SELECT
+    id,
+    portland_parse_flag(retired_or_cert_revoked),
+    portland_parse_date(retired_or_cert_revoked_date),
+    CASE WHEN TRIM(hire_year) ~ '^\d{4}$' THEN TRIM(hire_year)::INTEGER END,
+    portland_parse_date(hire_date),
+    portland_parse_date(state_cert_date),
+    state_cert_level,
+    employee_id,
+    helmet_id,
+    helmet_id_three_digit,
+    officer_rank,
+    first_name,
+    last_name,
+    gender,
+    badge,
+    portland_parse_flag(cops_photo_has_photo),
+    portland_parse_flag(rrt),
+    portland_parse_flag(rrt_2016),
+    portland_parse_flag(rrt_2018_niiya_email),
+    portland_parse_flag(rrt_2018),
+    portland_parse_flag(rrt_2019),
+    portland_parse_flag(rrt_2020),
+    portland_parse_flag(sound_truck_training_2020),
+    portland_parse_flag(instructed_for_dpsst),
+    portland_parse_flag(instructed_for_less_lethal),
+    cops_photo_profile_link,
+    portland_parse_flag(involved_in_ois_uof),
+    notes,
+    salary,
+    parse_amount(salary)
+FROM portland_officers_import;
 
+SELECT SETVAL(PG_GET_SERIAL_SEQUENCE('portland_officers', 'id'), MAX(id)) FROM portland_officers;
 
---------------------------------------------------------------------------------
--- Fuzzy search
---------------------------------------------------------------------------------
+-- Roster history: each roster release records whether an officer was employed on its date.
+-- New releases add rows for their date rather than columns.
+CREATE TABLE IF NOT EXISTS portland_roster_history (
+    officer_id                      INTEGER REFERENCES portland_officers (id),
+    date                            DATE,
+    employed                        BOOLEAN,
+    PRIMARY KEY (officer_id, date)
+);
 
--- First name
-CREATE OR REPLACE FUNCTION portland_fuzzy_search_officer_by_first_name_p(first_name  VARCHAR(100))
-    RETURNS SETOF portland_officers AS $$
-BEGIN
-    RETURN QUERY SELECT *
-    FROM portland_officers o
-    WHERE LOWER(o.first_name) % LOWER(portland_fuzzy_search_officer_by_first_name_p.first_name)
-    ORDER BY SIMILARITY(LOWER(o.first_name), LOWER(portland_fuzzy_search_officer_by_first_name_p.first_name)) DESC;
-    RETURN;
-END; $$
-LANGUAGE 'plpgsql'
-SECURITY DEFINER
-SET search_path =public, pg_temp;
+-- The releases so far are recorded as one column per date, and blank columns leave the
+-- officer's employment on that date unknown. The 6/1/20 release instead records the officers
+-- retired or resigned as of that date. The others were only employed then if they had been
+-- hired by that date, so officers hired later, or whose hire date is unknown, are left out.
+INSERT INTO portland_roster_history (officer_id, date, employed)
+SELECT
+    i.id,
+    r.date,
+    r.employed
+FROM portland_officers_import i
+JOIN portland_officers o ON o.id = i.id
+CROSS JOIN LATERAL (
+    VALUES
+        ('2021-03-12'::DATE, portland_parse_flag(i.employed_3_12_21)),
+        ('2020-12-28'::DATE, portland_parse_flag(i.employed_12_28_20)),
+        ('2020-10-01'::DATE, portland_parse_flag(i.employed_10_01_20)),
+        ('2020-06-01'::DATE, CASE
+            WHEN portland_parse_flag(i.retired_6_1_20) THEN FALSE
+            WHEN o.hire_date <= '2020-06-01' OR (o.hire_date IS NULL AND o.hire_year < 2020)
+                THEN NOT portland_parse_flag(i.retired_6_1_20)
+        END)
+) r (date, employed)
+WHERE r.employed IS NOT NULL;
 
+DROP TABLE portland_officers_import;
 
--- Last name
-CREATE OR REPLACE FUNCTION portland_fuzzy_search_officer_by_last_name_p(last_name  VARCHAR(100))
-    RETURNS SETOF portland_officers AS $$
-BEGIN
-    RETURN QUERY SELECT *
-    FROM portland_officers o
-    WHERE LOWER(o.last_name) % LOWER(portland_fuzzy_search_officer_by_last_name_p.last_name)
-    ORDER BY SIMILARITY(LOWER(o.last_name), LOWER(portland_fuzzy_search_officer_by_last_name_p.last_name)) DESC;
-    RETURN;
-END; $$
-LANGUAGE 'plpgsql'
-SECURITY DEFINER
-SET search_path =public, pg_temp;
+-- portland_roster lists the officers employed on each roster date
+CREATE OR REPLACE VIEW portland_roster AS
+SELECT
+    h.date,
+    h.officer_id,
+    o.officer_rank title
+FROM portland_roster_history h
+JOIN portland_officers o ON o.id = h.officer_id
+WHERE h.employed;
 
--- Full Name
-CREATE OR REPLACE FUNCTION portland_fuzzy_search_officer_by_name_p(full_name_v  VARCHAR(100))
-    RETURNS SETOF portland_officers AS $$
-BEGIN
-    RETURN QUERY SELECT *
-    FROM portland_officers o
-    WHERE LOWER(o.first_name || ' ' || o.last_name) % LOWER(portland_fuzzy_search_officer_by_name_p.full_name_v)
-    ORDER BY SIMILARITY(LOWER(o.first_name || ' ' || o.last_name), LOWER(portland_fuzzy_search_officer_by_name_p.full_name_v)) DESC;
-    RETURN;
-END; $$
-LANGUAGE 'plpgsql'
-SECURITY DEFINER
-SET search_path =public, pg_temp;
+-- portland_roster_status describes each officer's place on the rosters: the latest roster
+-- they were employed on, whether that is the latest roster released, and the whole years
+-- of service from their hire date to it.
+CREATE OR REPLACE VIEW portland_roster_status AS
+WITH max_roster AS (SELECT MAX(date) max_date FROM portland_roster_history)
+SELECT
+    o.id officer_id,
+    r.last_roster_date,
+    COALESCE(r.last_roster_date = m.max_date, FALSE) is_current,
+    DATE_PART('year', AGE(r.last_roster_date, o.hire_date))::INTEGER tenure_years
+FROM portland_officers o
+LEFT JOIN (
+    SELECT
+        officer_id,
+        MAX(date) last_roster_date
+    FROM portland_roster
+    GROUP BY officer_id
+) r ON r.officer_id = o.id
+CROSS JOIN max_roster m;
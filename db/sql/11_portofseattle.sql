@@ -2,6 +2,8 @@ CREATE TABLE IF NOT EXISTS port_of_seattle_officers (
     id            SERIAL PRIMARY KEY,
     badge_number  VARCHAR(50),
     name          VARCHAR(100),
+    first_name    VARCHAR(100),
+    last_name     VARCHAR(100),
     rank          VARCHAR(50),
     hire_date     INTEGER,
     unit          VARCHAR(100)
@@ -9,3 +11,21 @@ CREATE TABLE IF NOT EXISTS port_of_seattle_officers (
 
 COPY port_of_seattle_officers (badge_number,name,hire_date,rank,unit)
 FROM '/tmp/port_of_seattle.csv' DELIMITER ',' CSV HEADER;
+
+-- The roster records names in a single column, mostly as "Last, First" and otherwise as
+-- "First Last", with irregular whitespace. Names are split into first and last names so
+-- they can be searched like every other department's. Anything after the comma, middle
+-- initials included, is kept as the first name.
+UPDATE port_of_seattle_officers
+SET name = TRIM(REGEXP_REPLACE(name, '[[:space:]]+', ' ', 'g'));
+
+UPDATE port_of_seattle_officers
+SET
+    last_name = CASE
+        WHEN name LIKE '%,%' THEN NULLIF(TRIM(SPLIT_PART(name, ',', 1)), '')
+        ELSE SUBSTRING(name FROM '([^ ]+)$')
+    END,
+    first_name = CASE
+        WHEN name LIKE '%,%' THEN NULLIF(TRIM(SUBSTRING(name FROM ',(.*)$')), '')
+        ELSE SUBSTRING(name FROM '^(.*) [^ ]+$')
+    END;
@@ -0,0 +1,39 @@
+-- SPD precincts and the roster units assigned to them. Officers are mapped to a
+-- precinct by matching their unit description (e.g. "NORTH PCT 2ND W - B/N RELIEF")
+-- against unit_description_pattern.
+--
+-- Boundaries are simplified outlines of the SPD precinct map and are intended for
+-- display at city scale only.
+CREATE TABLE IF NOT EXISTS seattle_precincts (
+    id                          SERIAL PRIMARY KEY,
+    name                        VARCHAR(20),
+    unit_description_pattern    VARCHAR(50),
+    geometry                    JSONB
+);
+
+INSERT INTO seattle_precincts (name, unit_description_pattern, geometry) VALUES
+(
+    'North',
+    'NORTH PCT%',
+    '{"type":"Polygon","coordinates":[[[-122.4036,47.7341],[-122.2445,47.7341],[-122.2544,47.6602],[-122.2818,47.6475],[-122.3218,47.6475],[-122.3488,47.6515],[-122.3979,47.6652],[-122.4298,47.6760],[-122.4036,47.7341]]]}'
+),
+(
+    'West',
+    'WEST PCT%',
+    '{"type":"Polygon","coordinates":[[[-122.4298,47.6760],[-122.3979,47.6652],[-122.3488,47.6515],[-122.3218,47.6475],[-122.3252,47.6214],[-122.3208,47.6068],[-122.3226,47.5960],[-122.3370,47.5795],[-122.3563,47.5852],[-122.3844,47.6220],[-122.4359,47.6616],[-122.4298,47.6760]]]}'
+),
+(
+    'East',
+    'EAST PCT%',
+    '{"type":"Polygon","coordinates":[[[-122.3218,47.6475],[-122.2818,47.6475],[-122.2747,47.6263],[-122.2818,47.5993],[-122.2892,47.5892],[-122.3077,47.5892],[-122.3226,47.5960],[-122.3208,47.6068],[-122.3252,47.6214],[-122.3218,47.6475]]]}'
+),
+(
+    'South',
+    'SOUTH PCT%',
+    '{"type":"Polygon","coordinates":[[[-122.3370,47.5795],[-122.3226,47.5960],[-122.3077,47.5892],[-122.2892,47.5892],[-122.2818,47.5993],[-122.2568,47.5687],[-122.2447,47.5194],[-122.2600,47.4996],[-122.3042,47.4996],[-122.3250,47.5150],[-122.3370,47.5460],[-122.3370,47.5795]]]}'
+),
+(
+    'Southwest',
+    'SOUTHWEST PCT%',
+    '{"type":"Polygon","coordinates":[[[-122.3563,47.5852],[-122.3370,47.5795],[-122.3370,47.5460],[-122.3250,47.5150],[-122.3042,47.4996],[-122.3651,47.4996],[-122.3986,47.5148],[-122.4108,47.5574],[-122.4222,47.5765],[-122.3985,47.5862],[-122.3563,47.5852]]]}'
+);
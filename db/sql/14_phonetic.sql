@@ -0,0 +1,65 @@
+-- Phonetic name matching used by the fuzzy searches' phonetic mode. Every officer's names
+-- are stored alongside the primary and alternate Double Metaphone codes of each of their
+-- words, so names that sound alike ("Schaefer", "Shafer") can be matched.
+CREATE EXTENSION IF NOT EXISTS fuzzystrmatch;
+
+-- phonetic_codes returns the distinct Double Metaphone codes of every word in name
+CREATE OR REPLACE FUNCTION phonetic_codes(name TEXT)
+    RETURNS TEXT[] AS $$
+    SELECT COALESCE(ARRAY_AGG(DISTINCT code ORDER BY code), '{}')
+    FROM REGEXP_SPLIT_TO_TABLE(COALESCE(name, ''), '[^[:alpha:]]+') word,
+    LATERAL (VALUES (dmetaphone(word)), (dmetaphone_alt(word))) codes(code)
+    WHERE code <> '';
+$$
+LANGUAGE SQL
+IMMUTABLE;
+
+-- phonetic_match reports whether every word of query shares a Double Metaphone code with codes
+CREATE OR REPLACE FUNCTION phonetic_match(codes TEXT[], query TEXT)
+    RETURNS BOOLEAN AS $$
+    SELECT COALESCE(BOOL_AND(codes && ARRAY[dmetaphone(word), dmetaphone_alt(word)]), FALSE)
+    FROM REGEXP_SPLIT_TO_TABLE(COALESCE(query, ''), '[^[:alpha:]]+') word
+    WHERE word <> '';
+$$
+LANGUAGE SQL
+IMMUTABLE;
+
+ALTER TABLE seattle_officers
+    ADD COLUMN first_name_phonetic TEXT[] GENERATED ALWAYS AS (phonetic_codes(first_name)) STORED,
+    ADD COLUMN last_name_phonetic TEXT[] GENERATED ALWAYS AS (phonetic_codes(last_name)) STORED;
+
+ALTER TABLE tacoma_officers
+    ADD COLUMN first_name_phonetic TEXT[] GENERATED ALWAYS AS (phonetic_codes(first_name)) STORED,
+    ADD COLUMN last_name_phonetic TEXT[] GENERATED ALWAYS AS (phonetic_codes(last_name)) STORED;
+
+ALTER TABLE portland_officers
+    ADD COLUMN first_name_phonetic TEXT[] GENERATED ALWAYS AS (phonetic_codes(first_name)) STORED,
+    ADD COLUMN last_name_phonetic TEXT[] GENERATED ALWAYS AS (phonetic_codes(last_name)) STORED;
+
+ALTER TABLE auburn_officers
+    ADD COLUMN first_name_phonetic TEXT[] GENERATED ALWAYS AS (phonetic_codes(first_name)) STORED,
+    ADD COLUMN last_name_phonetic TEXT[] GENERATED ALWAYS AS (phonetic_codes(last_name)) STORED;
+
+ALTER TABLE lakewood_officers
+    ADD COLUMN first_name_phonetic TEXT[] GENERATED ALWAYS AS (phonetic_codes(first_name)) STORED,
+    ADD COLUMN last_name_phonetic TEXT[] GENERATED ALWAYS AS (phonetic_codes(last_name)) STORED;
+
+ALTER TABLE olympia_officers
+    ADD COLUMN first_name_phonetic TEXT[] GENERATED ALWAYS AS (phonetic_codes(first_name)) STORED,
+    ADD COLUMN last_name_phonetic TEXT[] GENERATED ALWAYS AS (phonetic_codes(last_name)) STORED;
+
+ALTER TABLE bellevue_officers
+    ADD COLUMN first_name_phonetic TEXT[] GENERATED ALWAYS AS (phonetic_codes(first_name)) STORED,
+    ADD COLUMN last_name_phonetic TEXT[] GENERATED ALWAYS AS (phonetic_codes(last_name)) STORED;
+
+ALTER TABLE renton_officers
+    ADD COLUMN first_name_phonetic TEXT[] GENERATED ALWAYS AS (phonetic_codes(first_name)) STORED,
+    ADD COLUMN last_name_phonetic TEXT[] GENERATED ALWAYS AS (phonetic_codes(last_name)) STORED;
+
+ALTER TABLE thurston_officers
+    ADD COLUMN first_name_phonetic TEXT[] GENERATED ALWAYS AS (phonetic_codes(first_name)) STORED,
+    ADD COLUMN last_name_phonetic TEXT[] GENERATED ALWAYS AS (phonetic_codes(last_name)) STORED;
+
+ALTER TABLE port_of_seattle_officers
+    ADD COLUMN first_name_phonetic TEXT[] GENERATED ALWAYS AS (phonetic_codes(first_name)) STORED,
+    ADD COLUMN last_name_phonetic TEXT[] GENERATED ALWAYS AS (phonetic_codes(last_name)) STORED;
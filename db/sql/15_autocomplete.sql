@@ -0,0 +1,52 @@
+-- Prefix indexes backing the autocomplete searches. Suggestions are looked up by the start of
+-- an officer's full name or of their last name, so both are indexed lowercased with
+-- text_pattern_ops, letting LIKE 'prefix%' patterns use an index scan.
+CREATE INDEX IF NOT EXISTS seattle_officers_name_prefix_idx
+    ON seattle_officers (LOWER(first_name || ' ' || last_name) text_pattern_ops);
+CREATE INDEX IF NOT EXISTS seattle_officers_last_name_prefix_idx
+    ON seattle_officers (LOWER(last_name) text_pattern_ops);
+
+CREATE INDEX IF NOT EXISTS tacoma_officers_name_prefix_idx
+    ON tacoma_officers (LOWER(first_name || ' ' || last_name) text_pattern_ops);
+CREATE INDEX IF NOT EXISTS tacoma_officers_last_name_prefix_idx
+    ON tacoma_officers (LOWER(last_name) text_pattern_ops);
+
+CREATE INDEX IF NOT EXISTS portland_officers_name_prefix_idx
+    ON portland_officers (LOWER(first_name || ' ' || last_name) text_pattern_ops);
+CREATE INDEX IF NOT EXISTS portland_officers_last_name_prefix_idx
+    ON portland_officers (LOWER(last_name) text_pattern_ops);
+
+CREATE INDEX IF NOT EXISTS auburn_officers_name_prefix_idx
+    ON auburn_officers (LOWER(first_name || ' ' || last_name) text_pattern_ops);
+CREATE INDEX IF NOT EXISTS auburn_officers_last_name_prefix_idx
+    ON auburn_officers (LOWER(last_name) text_pattern_ops);
+
+CREATE INDEX IF NOT EXISTS lakewood_officers_name_prefix_idx
+    ON lakewood_officers (LOWER(first_name || ' ' || last_name) text_pattern_ops);
+CREATE INDEX IF NOT EXISTS lakewood_officers_last_name_prefix_idx
+    ON lakewood_officers (LOWER(last_name) text_pattern_ops);
+
+CREATE INDEX IF NOT EXISTS olympia_officers_name_prefix_idx
+    ON olympia_officers (LOWER(first_name || ' ' || last_name) text_pattern_ops);
+CREATE INDEX IF NOT EXISTS olympia_officers_last_name_prefix_idx
+    ON olympia_officers (LOWER(last_name) text_pattern_ops);
+
+CREATE INDEX IF NOT EXISTS bellevue_officers_name_prefix_idx
+    ON bellevue_officers (LOWER(first_name || ' ' || last_name) text_pattern_ops);
+CREATE INDEX IF NOT EXISTS bellevue_officers_last_name_prefix_idx
+    ON bellevue_officers (LOWER(last_name) text_pattern_ops);
+
+CREATE INDEX IF NOT EXISTS renton_officers_name_prefix_idx
+    ON renton_officers (LOWER(first_name || ' ' || last_name) text_pattern_ops);
+CREATE INDEX IF NOT EXISTS renton_officers_last_name_prefix_idx
+    ON renton_officers (LOWER(last_name) text_pattern_ops);
+
+CREATE INDEX IF NOT EXISTS thurston_officers_name_prefix_idx
+    ON thurston_officers (LOWER(first_name || ' ' || last_name) text_pattern_ops);
+CREATE INDEX IF NOT EXISTS thurston_officers_last_name_prefix_idx
+    ON thurston_officers (LOWER(last_name) text_pattern_ops);
+
+CREATE INDEX IF NOT EXISTS port_of_seattle_officers_name_prefix_idx
+    ON port_of_seattle_officers (LOWER(first_name || ' ' || last_name) text_pattern_ops);
+CREATE INDEX IF NOT EXISTS port_of_seattle_officers_last_name_prefix_idx
+    ON port_of_seattle_officers (LOWER(last_name) text_pattern_ops);
@@ -0,0 +1,71 @@
+-- Name normalization used by the strict and fuzzy name searches. Names are compared without
+-- case, diacritics or punctuation, so "Muñoz" matches "Munoz" and "O'Brien" matches "OBrien",
+-- and hyphenated names are split into words, so "Smith-Jones" is found searching "Jones".
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+-- normalize_name lowercases name, strips its diacritics, apostrophes and periods, and turns
+-- hyphens and runs of whitespace into single spaces. The unaccent dictionary is named
+-- explicitly so the function can be declared IMMUTABLE and back generated columns.
+CREATE OR REPLACE FUNCTION normalize_name(name TEXT)
+    RETURNS TEXT AS $$
+    SELECT TRIM(REGEXP_REPLACE(
+        REGEXP_REPLACE(LOWER(public.unaccent('public.unaccent'::REGDICTIONARY, name)), '[''’`.]', '', 'g'),
+        '[-‐–—[:space:]]+', ' ', 'g'
+    ));
+$$
+LANGUAGE SQL
+IMMUTABLE;
+
+-- name_like reports whether a normalized name, or any single word of it, matches the LIKE
+-- pattern once the pattern is normalized the same way
+CREATE OR REPLACE FUNCTION name_like(normalized TEXT, pattern TEXT)
+    RETURNS BOOLEAN AS $$
+    SELECT normalized LIKE normalize_name(pattern)
+        OR EXISTS (
+            SELECT 1
+            FROM REGEXP_SPLIT_TO_TABLE(normalized, ' ') word
+            WHERE word LIKE normalize_name(pattern)
+        );
+$$
+LANGUAGE SQL
+IMMUTABLE;
+
+ALTER TABLE seattle_officers
+    ADD COLUMN first_name_normalized TEXT GENERATED ALWAYS AS (normalize_name(first_name)) STORED,
+    ADD COLUMN last_name_normalized TEXT GENERATED ALWAYS AS (normalize_name(last_name)) STORED;
+
+ALTER TABLE tacoma_officers
+    ADD COLUMN first_name_normalized TEXT GENERATED ALWAYS AS (normalize_name(first_name)) STORED,
+    ADD COLUMN last_name_normalized TEXT GENERATED ALWAYS AS (normalize_name(last_name)) STORED;
+
+ALTER TABLE portland_officers
+    ADD COLUMN first_name_normalized TEXT GENERATED ALWAYS AS (normalize_name(first_name)) STORED,
+    ADD COLUMN last_name_normalized TEXT GENERATED ALWAYS AS (normalize_name(last_name)) STORED;
+
+ALTER TABLE auburn_officers
+    ADD COLUMN first_name_normalized TEXT GENERATED ALWAYS AS (normalize_name(first_name)) STORED,
+    ADD COLUMN last_name_normalized TEXT GENERATED ALWAYS AS (normalize_name(last_name)) STORED;
+
+ALTER TABLE lakewood_officers
+    ADD COLUMN first_name_normalized TEXT GENERATED ALWAYS AS (normalize_name(first_name)) STORED,
+    ADD COLUMN last_name_normalized TEXT GENERATED ALWAYS AS (normalize_name(last_name)) STORED;
+
+ALTER TABLE olympia_officers
+    ADD COLUMN first_name_normalized TEXT GENERATED ALWAYS AS (normalize_name(first_name)) STORED,
+    ADD COLUMN last_name_normalized TEXT GENERATED ALWAYS AS (normalize_name(last_name)) STORED;
+
+ALTER TABLE bellevue_officers
+    ADD COLUMN first_name_normalized TEXT GENERATED ALWAYS AS (normalize_name(first_name)) STORED,
+    ADD COLUMN last_name_normalized TEXT GENERATED ALWAYS AS (normalize_name(last_name)) STORED;
+
+ALTER TABLE renton_officers
+    ADD COLUMN first_name_normalized TEXT GENERATED ALWAYS AS (normalize_name(first_name)) STORED,
+    ADD COLUMN last_name_normalized TEXT GENERATED ALWAYS AS (normalize_name(last_name)) STORED;
+
+ALTER TABLE thurston_officers
+    ADD COLUMN first_name_normalized TEXT GENERATED ALWAYS AS (normalize_name(first_name)) STORED,
+    ADD COLUMN last_name_normalized TEXT GENERATED ALWAYS AS (normalize_name(last_name)) STORED;
+
+ALTER TABLE port_of_seattle_officers
+    ADD COLUMN first_name_normalized TEXT GENERATED ALWAYS AS (normalize_name(first_name)) STORED,
+    ADD COLUMN last_name_normalized TEXT GENERATED ALWAYS AS (normalize_name(last_name)) STORED;
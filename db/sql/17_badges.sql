@@ -0,0 +1,43 @@
+-- Badge normalization used by the badge lookups. Rosters record badges inconsistently, so
+-- "06248", "6248" and "#6248" all name the same badge. Every badge is stored alongside its
+-- normalized form, and badges searched are normalized the same way before being compared.
+
+-- normalize_badge uppercases badge and strips its whitespace, leading "#", "BADGE", "DPSST"
+-- or "NO." prefixes and leading zeros. Must be kept in sync with NormalizeBadge in the API.
+CREATE OR REPLACE FUNCTION normalize_badge(badge TEXT)
+    RETURNS TEXT AS $$
+    SELECT REGEXP_REPLACE(
+        REGEXP_REPLACE(UPPER(REGEXP_REPLACE(badge, '[[:space:]]+', '', 'g')), '^(BADGE|DPSST|NO\.|#)+', ''),
+        '^0+(.)', '\1'
+    );
+$$
+LANGUAGE SQL
+IMMUTABLE;
+
+ALTER TABLE seattle_officers
+    ADD COLUMN badge_normalized TEXT GENERATED ALWAYS AS (normalize_badge(badge)) STORED;
+CREATE INDEX IF NOT EXISTS seattle_officers_badge_normalized_idx ON seattle_officers (badge_normalized text_pattern_ops);
+
+ALTER TABLE portland_officers
+    ADD COLUMN badge_normalized TEXT GENERATED ALWAYS AS (normalize_badge(badge)) STORED;
+CREATE INDEX IF NOT EXISTS portland_officers_badge_normalized_idx ON portland_officers (badge_normalized text_pattern_ops);
+
+ALTER TABLE auburn_officers
+    ADD COLUMN badge_normalized TEXT GENERATED ALWAYS AS (normalize_badge(badge)) STORED;
+CREATE INDEX IF NOT EXISTS auburn_officers_badge_normalized_idx ON auburn_officers (badge_normalized text_pattern_ops);
+
+ALTER TABLE olympia_officers
+    ADD COLUMN badge_normalized TEXT GENERATED ALWAYS AS (normalize_badge(badge)) STORED;
+CREATE INDEX IF NOT EXISTS olympia_officers_badge_normalized_idx ON olympia_officers (badge_normalized text_pattern_ops);
+
+ALTER TABLE bellevue_officers
+    ADD COLUMN badge_normalized TEXT GENERATED ALWAYS AS (normalize_badge(badge)) STORED;
+CREATE INDEX IF NOT EXISTS bellevue_officers_badge_normalized_idx ON bellevue_officers (badge_normalized text_pattern_ops);
+
+ALTER TABLE renton_officers
+    ADD COLUMN badge_normalized TEXT GENERATED ALWAYS AS (normalize_badge(badge_number)) STORED;
+CREATE INDEX IF NOT EXISTS renton_officers_badge_normalized_idx ON renton_officers (badge_normalized text_pattern_ops);
+
+ALTER TABLE port_of_seattle_officers
+    ADD COLUMN badge_normalized TEXT GENERATED ALWAYS AS (normalize_badge(badge_number)) STORED;
+CREATE INDEX IF NOT EXISTS port_of_seattle_officers_badge_normalized_idx ON port_of_seattle_officers (badge_normalized text_pattern_ops);
@@ -0,0 +1,110 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+// Test autocomplete endpoints
+func testAutocomplete(ctx context.Context, t *testing.T, profile string) {
+	t.Parallel()
+	for _, tt := range [...]struct {
+		genericTestOptions
+		department string
+		prefix     string
+		limit      string
+	}{
+		{
+			genericTestOptions: genericTestOptions{
+				name:              "NoPrefix",
+				expectedStatus:    http.StatusBadRequest,
+				expectedBody:      []byte("prefix is required"),
+				expectedBodyCheck: EqualsBytes,
+			},
+			department: "seattle",
+		},
+		{
+			genericTestOptions: genericTestOptions{
+				name:              "InvalidLimit",
+				expectedStatus:    http.StatusBadRequest,
+				expectedBody:      []byte("limit must be a whole number between 1 and 25"),
+				expectedBodyCheck: EqualsBytes,
+			},
+			department: "seattle",
+			prefix:     "kel",
+			limit:      "100",
+		},
+		{
+			genericTestOptions: genericTestOptions{
+				name:               "SeattleLastNamePrefix",
+				expectedStatus:     http.StatusOK,
+				expectedBodyCheck:  GreaterThanLength,
+				expectedBodyLength: 1,
+				expectedFields:     []string{"name"},
+			},
+			department: "seattle",
+			prefix:     "kel",
+		},
+		{
+			genericTestOptions: genericTestOptions{
+				name:               "SeattleFullNamePrefix",
+				expectedStatus:     http.StatusOK,
+				expectedBodyCheck:  GreaterThanLength,
+				expectedBodyLength: 0,
+				expectedFields:     []string{"name"},
+			},
+			department: "seattle",
+			prefix:     "james k",
+		},
+		{
+			genericTestOptions: genericTestOptions{
+				name:               "SeattleLimit",
+				expectedStatus:     http.StatusOK,
+				expectedBodyCheck:  EqualsLength,
+				expectedBodyLength: 2,
+				expectedFields:     []string{"name"},
+			},
+			department: "seattle",
+			prefix:     "j",
+			limit:      "2",
+		},
+		{
+			genericTestOptions: genericTestOptions{
+				name:               "WildcardsMatchedLiterally",
+				expectedStatus:     http.StatusOK,
+				expectedBodyCheck:  EqualsLength,
+				expectedBodyLength: 0,
+			},
+			department: "seattle",
+			prefix:     "%",
+		},
+		{
+			genericTestOptions: genericTestOptions{
+				name:               "PortOfSeattlePrefix",
+				expectedStatus:     http.StatusOK,
+				expectedBodyCheck:  GreaterThanLength,
+				expectedBodyLength: 0,
+				expectedFields:     []string{"name"},
+			},
+			department: "port_of_seattle",
+			prefix:     "a",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			res, _ := http.Get(fmt.Sprintf("%s/%s/officer/autocomplete?prefix=%s&limit=%s", testServer, tt.department, url.QueryEscape(tt.prefix), tt.limit))
+
+			if res.StatusCode != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", tt.expectedStatus, res.StatusCode)
+			}
+
+			defer res.Body.Close()
+			resp, _ := ioutil.ReadAll(res.Body)
+
+			checkBody(resp, tt.genericTestOptions, t)
+		})
+	}
+}
@@ -36,6 +36,8 @@ func TestIntegrations(t *testing.T) {
 			{"TestSeattleStrict", testSeattleStrict},
 			{"TestSeattleFuzzy", testSeattleFuzzy},
 			{"TestSeattleHistorical", testSeattleHistorical},
+			{"TestSeattlePrecincts", testSeattlePrecincts},
+			{"TestSeattlePromotions", testSeattlePromotions},
 			{"TestAuburnStrict", testAuburnStrict},
 			{"TestAuburnFuzzy", testAuburnFuzzy},
 			{"TestBellevueStrict", testBellevueStrict},
@@ -46,12 +48,18 @@ func TestIntegrations(t *testing.T) {
 			{"TestOlympiaFuzzy", testOlympiaFuzzy},
 			{"TestPortOfSeattleStrict", testPortOfSeattleStrict},
 			{"TestPortOfSeattleFuzzy", testPortOfSeattleFuzzy},
+			{"TestPortlandStrict", testPortlandStrict},
+			{"TestPortlandHistorical", testPortlandHistorical},
+			{"TestPortlandRRT", testPortlandRRT},
 			{"TestRentonStrict", testRentonStrict},
 			{"TestRentonFuzzy", testRentonFuzzy},
 			{"TestTacomaStrict", testTacomaStrict},
 			{"TestTacomaFuzzy", testTacomaFuzzy},
 			{"TestThurstonStrict", testThurstonStrict},
 			{"TestThurstonFuzzy", testThurstonFuzzy},
+			{"TestHeadcount", testHeadcount},
+			{"TestSalary", testSalary},
+			{"TestAutocomplete", testAutocomplete},
 		}
 		for _, tc := range tests {
 			tc := tc
@@ -85,7 +93,7 @@ func testPing(ctx context.Context, t *testing.T, profile string) {
 // Test departments endpoint
 func testDepartments(ctx context.Context, t *testing.T, profile string) {
 	t.Parallel()
-	expectedResponse := []byte(`[{"id":"spd","name":"Seattle PD","last_available_roster_date":"2021-12-02","fields":[{"FieldName":"date","Label":"Roster Date"},{"FieldName":"badge","Label":"Badge"},{"FieldName":"first_name","Label":"First Name"},{"FieldName":"middle_name","Label":"Middle Name"},{"FieldName":"last_name","Label":"Last Name"},{"FieldName":"title","Label":"Title"},{"FieldName":"unit","Label":"Unit"},{"FieldName":"unit_description","Label":"Unit Description"},{"FieldName":"full_name","Label":"Full Name"},{"FieldName":"is_current","Label":"On Current Roster"}],"search_routes":{"exact":{"path":"/seattle/officer","query_params":["badge","first_name","last_name"]},"fuzzy":{"path":"/seattle/officer/search","query_params":["first_name","last_name"]},"historical-exact":{"path":"/seattle/officer/historical","query_params":["badge"]}}},{"id":"tpd","name":"Tacoma PD","last_available_roster_date":"2019","fields":[{"FieldName":"first_name","Label":"First Name"},{"FieldName":"last_name","Label":"Last Name"},{"FieldName":"title","Label":"Title"},{"FieldName":"department","Label":"Department"},{"FieldName":"salary","Label":"Salary 2019"}],"search_routes":{"exact":{"path":"/tacoma/officer","query_params":["first_name","last_name"]},"fuzzy":{"path":"/tacoma/officer/search","query_params":["first_name","last_name"]}}},{"id":"ppb","name":"Portland PB","last_available_roster_date":"2021-03-12","fields":[{"FieldName":"first_name","Label":"First Name"},{"FieldName":"last_name","Label":"Last Name"},{"FieldName":"gender","Label":"Gender"},{"FieldName":"officer_rank","Label":"Rank"},{"FieldName":"employee_id","Label":"Employee (Chest) ID"},{"FieldName":"helmet_id","Label":"Helmet #"},{"FieldName":"helmet_id_three_digit","Label":"3-Digit Helmet #"},{"FieldName":"salary","Label":"Fiscal Earnings 2019"},{"FieldName":"badge","Label":"Badge/DPSST Number"},{"FieldName":"cops_photo_profile_link","Label":"Cops.Photo Profile Link"},{"FieldName":"cops_photo_has_photo","Label":"Pic on Cops.photo (y/n)"},{"FieldName":"employed_3_12_21","Label":"Employed as of 3/12/21"},{"FieldName":"employed_12_28_20","Label":"Employed as of 12/28/20"},{"FieldName":"employed_10_01_20","Label":"Employed as of 10/01/20"},{"FieldName":"retired_6_1_20","Label":"Retired/Resigned as of 6/1/20"},{"FieldName":"retired_or_cert_revoked","Label":"Retired/Resigned as of 6/1/20 OR Cert Revoked (ever)"},{"FieldName":"retired_or_cert_revoked_date","Label":"Date of Cert Revoke"},{"FieldName":"hire_year","Label":"Hire Year"},{"FieldName":"hire_date","Label":"Hire Date"},{"FieldName":"state_cert_date","Label":"State Certification Date"},{"FieldName":"state_cert_level","Label":"State Certification Level"},{"FieldName":"rrt","Label":"RRT (Rapid Response Team) Member"},{"FieldName":"rrt_2016","Label":"RRT member as of 2016 via 2017 PPB AR"},{"FieldName":"rrt_2018_niiya_email","Label":"RRT member as of 2018 via Niiya Email"},{"FieldName":"rrt_2018","Label":"RRT Specific Training 2018"},{"FieldName":"rrt_2019","Label":"RRT Specific Training 2019"},{"FieldName":"rrt_2020","Label":"RRT Specific Training 2020"},{"FieldName":"sound_truck_training_2020","Label":"Sound Truck Training 2020"},{"FieldName":"instructed_for_dpsst","Label":"Has Instructed Course for DPSST 2017+"},{"FieldName":"instructed_for_less_lethal","Label":"Instructor for Less Lethal/Chemical Weapons Courses"},{"FieldName":"involved_in_ois_uof","Label":"Has Been Involved in OIS/Significant UoF Incident"},{"FieldName":"notes","Label":"Notes"}],"search_routes":{"exact":{"path":"/portland/officer","query_params":["badge","first_name","last_name","employee_id","helmet_id","helmet_id_three_digit"]},"fuzzy":{"path":"/portland/officer/search","query_params":["first_name","last_name"]}}},{"id":"apd","name":"Auburn PD","last_available_roster_date":"2021-06-07","fields":[{"FieldName":"date","Label":"Roster Date"},{"FieldName":"badge","Label":"Badge"},{"FieldName":"first_name","Label":"First Name"},{"FieldName":"last_name","Label":"Last Name"},{"FieldName":"title","Label":"Title"}],"search_routes":{"exact":{"path":"/auburn/officer","query_params":["badge","first_name","last_name"]},"fuzzy":{"path":"/auburn/officer/search","query_params":["first_name","last_name"]}}},{"id":"lpd","name":"Lakewood PD","last_available_roster_date":"2021-05-01","fields":[{"FieldName":"date","Label":"Roster Date"},{"FieldName":"title","Label":"Title"},{"FieldName":"last_name","Label":"Last Name"},{"FieldName":"first_name","Label":"First Name"},{"FieldName":"unit","Label":"Unit"},{"FieldName":"unit_descritpion","Label":"Unit Description"}],"search_routes":{"exact":{"path":"/lakewood/officer","query_params":["first_name","last_name"]},"fuzzy":{"path":"/lakewood/officer/search","query_params":["first_name","last_name"]}}},{"id":"rpd","name":"Renton PD","last_available_roster_date":"2021-05-01","fields":[{"FieldName":"last_name","Label":"Last Name"},{"FieldName":"first_name","Label":"First Name"},{"FieldName":"middle_name","Label":"Middle Name"},{"FieldName":"rank","Label":"Officer Rank"},{"FieldName":"department","Label":"Officer Department"},{"FieldName":"division","Label":"Officer Division"},{"FieldName":"shift","Label":"Shift"},{"FieldName":"additional_info","Label":"additional information (including retirement date)"},{"FieldName":"badge","Label":"Badge number"}],"search_routes":{"exact":{"path":"/renton/officer","query_params":["first_name","last_name"]},"fuzzy":{"path":"/renton/officer/search","query_params":["first_name","last_name"]}}},{"id":"tcsd","name":"Thurston County Sheriff's Department","last_available_roster_date":"2021-05-01","fields":[{"FieldName":"last_name","Label":"Last Name"},{"FieldName":"first_name","Label":"First Name"},{"FieldName":"title","Label":"Officer Title"},{"FieldName":"call_sign","Label":"Call Sign"}],"search_routes":{"exact":{"path":"/thurston_county/officer","query_params":["first_name","last_name"]},"fuzzy":{"path":"/thurston_county/officer/search","query_params":["first_name","last_name"]}}},{"id":"bpd","name":"Bellevue PD","last_available_roster_date":"2021-05-01","fields":[{"FieldName":"last_name","Label":"Last Name"},{"FieldName":"first_name","Label":"First Name"},{"FieldName":"title","Label":"Officer Title"},{"FieldName":"unit","Label":"Officer unit"},{"FieldName":"notes","Label":"additional information (including retirement date)"},{"FieldName":"badge","Label":"Badge number"}],"search_routes":{"exact":{"path":"/bellevue/officer","query_params":["badge","first_name","last_name"]},"fuzzy":{"path":"/bellevue/officer/search","query_params":["first_name","last_name"]}}},{"id":"pospd","name":"Port Of Seattle PD","last_available_roster_date":"2021-05-01","fields":[{"FieldName":"name","Label":"Full Name"},{"FieldName":"rank","Label":"Officer Title"},{"FieldName":"unit","Label":"Officer unit"},{"FieldName":"badge","Label":"Badge number"}],"search_routes":{"exact":{"path":"/port_of_seattle/officer","query_params":["badge","name"]},"fuzzy":{"path":"/port_of_seattle/officer/search","query_params":["name"]}}},{"id":"opd","name":"Olympia PD","last_available_roster_date":"2021-05-01","fields":[{"FieldName":"date","Label":"Roster Date"},{"FieldName":"first_name","Label":"First Name"},{"FieldName":"last_name","Label":"Last Name"},{"FieldName":"title","Label":"Title"},{"FieldName":"unit","Label":"Unit"},{"FieldName":"badge","Label":"Badge"}],"search_routes":{"exact":{"path":"/olympia/officer","query_params":["badge","first_name","last_name"]},"fuzzy":{"path":"/olympia/officer/search","query_params":["first_name","last_name"]}}}]` + "\n")
+	expectedResponse := []byte(`[{"id":"spd","name":"Seattle PD","last_available_roster_date":"2021-12-02","fields":[{"FieldName":"date","Label":"Roster Date"},{"FieldName":"badge","Label":"Badge"},{"FieldName":"first_name","Label":"First Name"},{"FieldName":"middle_name","Label":"Middle Name"},{"FieldName":"last_name","Label":"Last Name"},{"FieldName":"title","Label":"Title"},{"FieldName":"canonical_rank","Label":"Canonical Rank"},{"FieldName":"unit","Label":"Unit"},{"FieldName":"unit_description","Label":"Unit Description"},{"FieldName":"full_name","Label":"Full Name"},{"FieldName":"is_current","Label":"On Current Roster"}],"search_routes":{"autocomplete":{"path":"/seattle/officer/autocomplete","query_params":["prefix","limit"]},"exact":{"path":"/seattle/officer","query_params":["q","badge","badge_mode","first_name","middle_name","last_name","full_name","nicknames","title","unit","unit_description","mode","rank"]},"fuzzy":{"path":"/seattle/officer/search","query_params":["q","first_name","middle_name","last_name","full_name","nicknames","mode","min_score","threshold","sort","rank"]},"historical-exact":{"path":"/seattle/officer/historical","query_params":["badge","rank"]}}},{"id":"tpd","name":"Tacoma PD","last_available_roster_date":"2019","fields":[{"FieldName":"first_name","Label":"First Name"},{"FieldName":"last_name","Label":"Last Name"},{"FieldName":"title","Label":"Title"},{"FieldName":"canonical_rank","Label":"Canonical Rank"},{"FieldName":"department","Label":"Department"},{"FieldName":"salary","Label":"Salary 2019"},{"FieldName":"salary_amount","Label":"Salary 2019 (Amount)"}],"search_routes":{"autocomplete":{"path":"/tacoma/officer/autocomplete","query_params":["prefix","limit"]},"exact":{"path":"/tacoma/officer","query_params":["q","first_name","last_name","nicknames","title","salary_min","salary_max","mode","rank"]},"fuzzy":{"path":"/tacoma/officer/search","query_params":["q","first_name","last_name","nicknames","mode","min_score","threshold","sort","rank"]}}},{"id":"ppb","name":"Portland PB","last_available_roster_date":"2021-03-12","fields":[{"FieldName":"first_name","Label":"First Name"},{"FieldName":"last_name","Label":"Last Name"},{"FieldName":"gender","Label":"Gender"},{"FieldName":"officer_rank","Label":"Rank"},{"FieldName":"canonical_rank","Label":"Canonical Rank"},{"FieldName":"employee_id","Label":"Employee (Chest) ID"},{"FieldName":"helmet_id","Label":"Helmet #"},{"FieldName":"helmet_id_three_digit","Label":"3-Digit Helmet #"},{"FieldName":"salary","Label":"Fiscal Earnings 2019"},{"FieldName":"salary_amount","Label":"Fiscal Earnings 2019 (Amount)"},{"FieldName":"badge","Label":"Badge/DPSST Number"},{"FieldName":"cops_photo_profile_link","Label":"Cops.Photo Profile Link"},{"FieldName":"cops_photo_has_photo","Label":"Pic on Cops.photo (y/n)"},{"FieldName":"date","Label":"Last Roster Date"},{"FieldName":"is_current","Label":"On Current Roster"},{"FieldName":"tenure_years","Label":"Years of Service"},{"FieldName":"retired_or_cert_revoked","Label":"Retired/Resigned as of 6/1/20 OR Cert Revoked (ever)"},{"FieldName":"retired_or_cert_revoked_date","Label":"Date of Cert Revoke"},{"FieldName":"hire_year","Label":"Hire Year"},{"FieldName":"hire_date","Label":"Hire Date"},{"FieldName":"state_cert_date","Label":"State Certification Date"},{"FieldName":"state_cert_level","Label":"State Certification Level"},{"FieldName":"rrt","Label":"RRT (Rapid Response Team) Member"},{"FieldName":"rrt_2016","Label":"RRT member as of 2016 via 2017 PPB AR"},{"FieldName":"rrt_2018_niiya_email","Label":"RRT member as of 2018 via Niiya Email"},{"FieldName":"rrt_2018","Label":"RRT Specific Training 2018"},{"FieldName":"rrt_2019","Label":"RRT Specific Training 2019"},{"FieldName":"rrt_2020","Label":"RRT Specific Training 2020"},{"FieldName":"sound_truck_training_2020","Label":"Sound Truck Training 2020"},{"FieldName":"instructed_for_dpsst","Label":"Has Instructed Course for DPSST 2017+"},{"FieldName":"instructed_for_less_lethal","Label":"Instructor for Less Lethal/Chemical Weapons Courses"},{"FieldName":"involved_in_ois_uof","Label":"Has Been Involved in OIS/Significant UoF Incident"},{"FieldName":"notes","Label":"Notes"}],"search_routes":{"autocomplete":{"path":"/portland/officer/autocomplete","query_params":["prefix","limit"]},"exact":{"path":"/portland/officer","query_params":["q","id","badge","first_name","last_name","nicknames","employee_id","helmet_id","helmet_id_three_digit","title","rrt","sound_truck_training_2020","instructed_for_dpsst","instructed_for_less_lethal","involved_in_ois_uof","hire_year_min","hire_year_max","salary_min","salary_max","mode","rank"]},"fuzzy":{"path":"/portland/officer/search","query_params":["q","first_name","last_name","nicknames","mode","min_score","threshold","rank"]},"historical-exact":{"path":"/portland/officer/historical","query_params":["badge","rank"]},"rrt":{"path":"/portland/officer/rrt","query_params":["year","training","rrt","sound_truck_training_2020","instructed_for_dpsst","instructed_for_less_lethal","involved_in_ois_uof","rank"]}}},{"id":"apd","name":"Auburn PD","last_available_roster_date":"2021-06-07","fields":[{"FieldName":"date","Label":"Roster Date"},{"FieldName":"badge","Label":"Badge"},{"FieldName":"first_name","Label":"First Name"},{"FieldName":"last_name","Label":"Last Name"},{"FieldName":"title","Label":"Title"},{"FieldName":"canonical_rank","Label":"Canonical Rank"}],"search_routes":{"autocomplete":{"path":"/auburn/officer/autocomplete","query_params":["prefix","limit"]},"exact":{"path":"/auburn/officer","query_params":["q","badge","badge_mode","first_name","last_name","nicknames","title","mode","rank"]},"fuzzy":{"path":"/auburn/officer/search","query_params":["q","first_name","last_name","nicknames","mode","min_score","threshold","sort","rank"]}}},{"id":"lpd","name":"Lakewood PD","last_available_roster_date":"2021-05-01","fields":[{"FieldName":"date","Label":"Roster Date"},{"FieldName":"title","Label":"Title"},{"FieldName":"canonical_rank","Label":"Canonical Rank"},{"FieldName":"last_name","Label":"Last Name"},{"FieldName":"first_name","Label":"First Name"},{"FieldName":"unit","Label":"Unit"},{"FieldName":"unit_descritpion","Label":"Unit Description"}],"search_routes":{"autocomplete":{"path":"/lakewood/officer/autocomplete","query_params":["prefix","limit"]},"exact":{"path":"/lakewood/officer","query_params":["q","first_name","last_name","nicknames","title","unit","unit_description","mode","rank"]},"fuzzy":{"path":"/lakewood/officer/search","query_params":["q","first_name","last_name","nicknames","mode","min_score","threshold","sort","rank"]}}},{"id":"rpd","name":"Renton PD","last_available_roster_date":"2021-05-01","fields":[{"FieldName":"last_name","Label":"Last Name"},{"FieldName":"first_name","Label":"First Name"},{"FieldName":"middle_name","Label":"Middle Name"},{"FieldName":"rank","Label":"Officer Rank"},{"FieldName":"canonical_rank","Label":"Canonical Rank"},{"FieldName":"department","Label":"Officer Department"},{"FieldName":"division","Label":"Officer Division"},{"FieldName":"shift","Label":"Shift"},{"FieldName":"additional_info","Label":"additional information (including retirement date)"},{"FieldName":"badge","Label":"Badge number"}],"search_routes":{"autocomplete":{"path":"/renton/officer/autocomplete","query_params":["prefix","limit"]},"exact":{"path":"/renton/officer","query_params":["q","badge","badge_mode","first_name","middle_name","last_name","full_name","nicknames","title","department","division","shift","mode","rank"]},"fuzzy":{"path":"/renton/officer/search","query_params":["q","first_name","middle_name","last_name","full_name","nicknames","mode","min_score","threshold","rank"]}}},{"id":"tcsd","name":"Thurston County Sheriff's Department","last_available_roster_date":"2021-05-01","fields":[{"FieldName":"last_name","Label":"Last Name"},{"FieldName":"first_name","Label":"First Name"},{"FieldName":"title","Label":"Officer Title"},{"FieldName":"canonical_rank","Label":"Canonical Rank"},{"FieldName":"call_sign","Label":"Call Sign"}],"search_routes":{"autocomplete":{"path":"/thurston_county/officer/autocomplete","query_params":["prefix","limit"]},"exact":{"path":"/thurston_county/officer","query_params":["q","first_name","last_name","nicknames","title","call_sign","mode","rank"]},"fuzzy":{"path":"/thurston_county/officer/search","query_params":["q","first_name","last_name","nicknames","mode","min_score","threshold","rank"]}}},{"id":"bpd","name":"Bellevue PD","last_available_roster_date":"2021-05-01","fields":[{"FieldName":"last_name","Label":"Last Name"},{"FieldName":"first_name","Label":"First Name"},{"FieldName":"title","Label":"Officer Title"},{"FieldName":"canonical_rank","Label":"Canonical Rank"},{"FieldName":"unit","Label":"Officer unit"},{"FieldName":"notes","Label":"additional information (including retirement date)"},{"FieldName":"badge","Label":"Badge number"}],"search_routes":{"autocomplete":{"path":"/bellevue/officer/autocomplete","query_params":["prefix","limit"]},"exact":{"path":"/bellevue/officer","query_params":["q","badge","badge_mode","first_name","last_name","nicknames","title","unit","mode","rank"]},"fuzzy":{"path":"/bellevue/officer/search","query_params":["q","first_name","last_name","nicknames","mode","min_score","threshold","rank"]}}},{"id":"pospd","name":"Port Of Seattle PD","last_available_roster_date":"2021-05-01","fields":[{"FieldName":"name","Label":"Full Name"},{"FieldName":"first_name","Label":"First Name"},{"FieldName":"last_name","Label":"Last Name"},{"FieldName":"rank","Label":"Officer Title"},{"FieldName":"canonical_rank","Label":"Canonical Rank"},{"FieldName":"unit","Label":"Officer unit"},{"FieldName":"badge","Label":"Badge number"},{"FieldName":"hire_year","Label":"Hire Year"},{"FieldName":"tenure_years","Label":"Years of Service"}],"search_routes":{"autocomplete":{"path":"/port_of_seattle/officer/autocomplete","query_params":["prefix","limit"]},"exact":{"path":"/port_of_seattle/officer","query_params":["q","badge","badge_mode","first_name","last_name","nicknames","title","unit","hire_year_min","hire_year_max","mode","rank"]},"fuzzy":{"path":"/port_of_seattle/officer/search","query_params":["q","first_name","last_name","nicknames","mode","min_score","threshold","rank"]}}},{"id":"opd","name":"Olympia PD","last_available_roster_date":"2021-05-01","fields":[{"FieldName":"date","Label":"Roster Date"},{"FieldName":"first_name","Label":"First Name"},{"FieldName":"last_name","Label":"Last Name"},{"FieldName":"title","Label":"Title"},{"FieldName":"canonical_rank","Label":"Canonical Rank"},{"FieldName":"unit","Label":"Unit"},{"FieldName":"badge","Label":"Badge"}],"search_routes":{"autocomplete":{"path":"/olympia/officer/autocomplete","query_params":["prefix","limit"]},"exact":{"path":"/olympia/officer","query_params":["q","badge","badge_mode","first_name","last_name","nicknames","title","unit","mode","rank"]},"fuzzy":{"path":"/olympia/officer/search","query_params":["q","first_name","last_name","nicknames","mode","min_score","threshold","sort","rank"]}}}]` + "\n")
 	resp, err := http.Get(testServer + "/departments")
 	if err != nil {
 		t.Errorf("Unspecified error with request: %v", err)
@@ -114,13 +122,31 @@ const (
 type genericTestOptions struct {
 	name               string
 	firstName          string
+	middleName         string
 	lastName           string
-	searchName         string
+	fullName           string
 	badge              string
+	badgeMode          string
+	title              string
+	mode               string
+	nicknames          string
+	minScore           string
+	sort               string
+	threshold          string
+	q                  string
+	rank               string
+	hireYearMin        string
+	hireYearMax        string
 	expectedStatus     int
 	expectedBody       []byte
 	expectedBodyCheck  BodyCheck
 	expectedBodyLength int
+	// expectedFirst lists the values of the fields the first officer, or entry, returned must have
+	expectedFirst map[string]interface{}
+	// expectedFields lists the fields every officer, or entry, returned must include
+	expectedFields []string
+	// expectedAll lists the values of the fields every officer, or entry, returned must have
+	expectedAll map[string]interface{}
 }
 
 // Generic response checking function for testing
@@ -151,4 +177,42 @@ func checkBody(body []byte, testOptions genericTestOptions, t *testing.T) {
 	default:
 		t.Errorf("\nTest: %s\nUnknown body check passed", testOptions.name)
 	}
+	checkFields(body, testOptions, t)
+}
+
+// checkFields checks the officers, or entries such as stats, returned against the expected
+// fields and field values
+func checkFields(body []byte, testOptions genericTestOptions, t *testing.T) {
+	if len(testOptions.expectedFirst) == 0 && len(testOptions.expectedFields) == 0 && len(testOptions.expectedAll) == 0 {
+		return
+	}
+
+	var officers []map[string]interface{}
+	err := json.Unmarshal(body, &officers)
+	if err != nil {
+		t.Errorf("\nTest: %s\nUnexpected error unmarsheling JSON response: %v", testOptions.name, err)
+		return
+	}
+	if len(officers) == 0 {
+		t.Errorf("\nTest: %s\nExpected officers to check fields of; got none", testOptions.name)
+		return
+	}
+
+	for field, value := range testOptions.expectedFirst {
+		if officers[0][field] != value {
+			t.Errorf("\nTest: %s\nExpected first officer's %s %v; got %v", testOptions.name, field, value, officers[0][field])
+		}
+	}
+	for i, officer := range officers {
+		for _, field := range testOptions.expectedFields {
+			if _, ok := officer[field]; !ok {
+				t.Errorf("\nTest: %s\nExpected officer %d to include %s; got %v", testOptions.name, i, field, officer)
+			}
+		}
+		for field, value := range testOptions.expectedAll {
+			if officer[field] != value {
+				t.Errorf("\nTest: %s\nExpected officer %d's %s %v; got %v", testOptions.name, i, field, value, officer[field])
+			}
+		}
+	}
 }
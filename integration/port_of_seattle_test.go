@@ -15,7 +15,7 @@ func testPortOfSeattleStrict(ctx context.Context, t *testing.T, profile string)
 		{
 			name:              "StrictNoParams",
 			expectedStatus:    http.StatusBadRequest,
-			expectedBody:      []byte("name must be provided"),
+			expectedBody:      []byte("at least one of the following parameters must be provided: badge, first_name, last_name, hire_year_min, hire_year_max"),
 			expectedBodyCheck: EqualsBytes,
 		},
 		{
@@ -27,28 +27,71 @@ func testPortOfSeattleStrict(ctx context.Context, t *testing.T, profile string)
 		},
 		{
 			name:               "FirstNameStrictSearch",
-			searchName:         "Patrick",
+			firstName:          "Patrick",
 			expectedStatus:     http.StatusOK,
 			expectedBodyCheck:  GreaterThanLength,
 			expectedBodyLength: 1,
 		},
 		{
 			name:               "LastNameStrictSearch",
-			searchName:         "Addison",
+			lastName:           "Addison",
 			expectedStatus:     http.StatusOK,
 			expectedBodyCheck:  EqualsLength,
 			expectedBodyLength: 1,
+			expectedFirst:      map[string]interface{}{"last_name": "Addison"},
 		},
 		{
 			name:               "FirstAndLastNameStrictSearch",
-			searchName:         "Addison%2C%20Patrick",
+			firstName:          "Patrick",
+			lastName:           "Addison",
 			expectedStatus:     http.StatusOK,
 			expectedBodyCheck:  EqualsLength,
 			expectedBodyLength: 1,
+			expectedFirst:      map[string]interface{}{"first_name": "Patrick", "last_name": "Addison"},
+			expectedFields:     []string{"name"},
+		},
+		{
+			name:               "HireYearRangeStrictSearch",
+			hireYearMin:        "1900",
+			hireYearMax:        "2100",
+			expectedStatus:     http.StatusOK,
+			expectedBodyCheck:  GreaterThanLength,
+			expectedBodyLength: 0,
+			expectedFields:     []string{"hire_year"},
+		},
+		{
+			name:               "HireYearMaxStrictSearch",
+			hireYearMax:        "2100",
+			expectedStatus:     http.StatusOK,
+			expectedBodyCheck:  GreaterThanLength,
+			expectedBodyLength: 0,
+			expectedFields:     []string{"hire_year"},
+		},
+		{
+			name:               "HireYearOutOfRangeStrictSearch",
+			hireYearMin:        "2100",
+			expectedStatus:     http.StatusOK,
+			expectedBodyCheck:  EqualsLength,
+			expectedBodyLength: 0,
+		},
+		{
+			name:              "HireYearReversedStrictSearch",
+			hireYearMin:       "2020",
+			hireYearMax:       "2010",
+			expectedStatus:    http.StatusBadRequest,
+			expectedBody:      []byte("hire_year_min must not be greater than hire_year_max"),
+			expectedBodyCheck: EqualsBytes,
+		},
+		{
+			name:              "HireYearNotANumberStrictSearch",
+			hireYearMin:       "recent",
+			expectedStatus:    http.StatusBadRequest,
+			expectedBody:      []byte("hire_year_min must be a number"),
+			expectedBodyCheck: EqualsBytes,
 		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
-			res, _ := http.Get(fmt.Sprintf("%s/port_of_seattle/officer?badge=%s&name=%s", testServer, tt.badge, tt.searchName))
+			res, _ := http.Get(fmt.Sprintf("%s/port_of_seattle/officer?badge=%s&first_name=%s&last_name=%s&hire_year_min=%s&hire_year_max=%s", testServer, tt.badge, tt.firstName, tt.lastName, tt.hireYearMin, tt.hireYearMax))
 
 			if res.StatusCode != tt.expectedStatus {
 				t.Fatalf("\nTest: %s\nExpected status %d; got %d", tt.name, tt.expectedStatus, res.StatusCode)
@@ -69,33 +112,34 @@ func testPortOfSeattleFuzzy(ctx context.Context, t *testing.T, profile string) {
 		{
 			name:              "FuzzyNoParams",
 			expectedStatus:    http.StatusBadRequest,
-			expectedBody:      []byte("provided name must not be empty"),
+			expectedBody:      []byte("at least one of the following parameters must be provided: first_name, last_name"),
 			expectedBodyCheck: EqualsBytes,
 		},
 		{
 			name:               "FirstNameFuzzySearch",
-			searchName:         "Patrick",
+			firstName:          "Patrick",
 			expectedStatus:     http.StatusOK,
 			expectedBodyCheck:  GreaterThanLength,
 			expectedBodyLength: 1,
 		},
 		{
 			name:               "LastNameFuzzySearch",
-			searchName:         "Addison",
+			lastName:           "Addison",
 			expectedStatus:     http.StatusOK,
 			expectedBodyCheck:  EqualsLength,
 			expectedBodyLength: 1,
 		},
 		{
 			name:               "FirstAndLastNameFuzzySearch",
-			searchName:         "Patrick%20Addison",
+			firstName:          "Patrick",
+			lastName:           "Addison",
 			expectedStatus:     http.StatusOK,
 			expectedBodyCheck:  EqualsLength,
 			expectedBodyLength: 1,
 		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
-			res, _ := http.Get(fmt.Sprintf("%s/port_of_seattle/officer/search?name=%s", testServer, tt.searchName))
+			res, _ := http.Get(fmt.Sprintf("%s/port_of_seattle/officer/search?first_name=%s&last_name=%s", testServer, tt.firstName, tt.lastName))
 
 			if res.StatusCode != tt.expectedStatus {
 				t.Fatalf("\nTest: %s\nExpected status %d, got %d", tt.name, tt.expectedStatus, res.StatusCode)
@@ -0,0 +1,415 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+// portlandIDs lists the identifiers Portland officers are looked up by
+var portlandIDs = []string{"badge", "employee_id", "helmet_id", "helmet_id_three_digit"}
+
+// portlandSample returns a current Portland officer recorded with every identifier, whose
+// identifiers and names the Portland tests search for.
+func portlandSample(t *testing.T) map[string]interface{} {
+	res, err := http.Get(fmt.Sprintf("%s/portland/officer?last_name=*", testServer))
+	if err != nil {
+		t.Fatalf("Unspecified error with request: %v", err)
+	}
+	defer res.Body.Close()
+
+	var officers []map[string]interface{}
+	err = json.NewDecoder(res.Body).Decode(&officers)
+	if err != nil {
+		t.Fatalf("Unexpected error unmarsheling JSON response: %v", err)
+	}
+
+	for _, officer := range officers {
+		if officer["is_current"] != true || officer["first_name"] == nil || officer["last_name"] == nil {
+			continue
+		}
+		recorded := true
+		for _, id := range portlandIDs {
+			if value, ok := officer[id].(string); !ok || value == "" {
+				recorded = false
+			}
+		}
+		if recorded {
+			return officer
+		}
+	}
+	t.Fatalf("Expected a current Portland officer recorded with %v; got none of %d", portlandIDs, len(officers))
+	return nil
+}
+
+// checkPortlandSample checks the sample officer is among the officers returned, listing at
+// least matchedIDs among the identifiers it matched.
+func checkPortlandSample(body []byte, name string, sample map[string]interface{}, matchedIDs []string, t *testing.T) {
+	var officers []map[string]interface{}
+	err := json.Unmarshal(body, &officers)
+	if err != nil {
+		t.Errorf("\nTest: %s\nUnexpected error unmarsheling JSON response: %v", name, err)
+		return
+	}
+
+	for _, officer := range officers {
+		if officer["employee_id"] != sample["employee_id"] || officer["badge"] != sample["badge"] {
+			continue
+		}
+		matched := map[string]bool{}
+		if ids, ok := officer["matched_ids"].([]interface{}); ok {
+			for _, id := range ids {
+				matched[fmt.Sprint(id)] = true
+			}
+		}
+		for _, id := range matchedIDs {
+			if !matched[id] {
+				t.Errorf("\nTest: %s\nExpected officer to have matched %s; got %v", name, id, officer["matched_ids"])
+			}
+		}
+		return
+	}
+	t.Errorf("\nTest: %s\nExpected officer with employee_id %v among %d officers returned", name, sample["employee_id"], len(officers))
+}
+
+// Test Portland strict match endpoint
+func testPortlandStrict(ctx context.Context, t *testing.T, profile string) {
+	t.Parallel()
+	sample := portlandSample(t)
+	for _, tt := range [...]struct {
+		genericTestOptions
+		query string
+		// sampleMatchedIDs lists the identifiers the sample officer, which must be returned
+		// unless nil, matched
+		sampleMatchedIDs []string
+	}{
+		{
+			genericTestOptions: genericTestOptions{
+				name:              "NoParams",
+				expectedStatus:    http.StatusBadRequest,
+				expectedBody:      []byte("at least one of the following parameters must be provided: id, badge, first_name, last_name, hire_year_min, hire_year_max, salary_min, salary_max, rrt, sound_truck_training_2020, instructed_for_dpsst, instructed_for_less_lethal, involved_in_ois_uof"),
+				expectedBodyCheck: EqualsBytes,
+			},
+		},
+		{
+			genericTestOptions: genericTestOptions{
+				name:               "IdMatchesBadge",
+				expectedStatus:     http.StatusOK,
+				expectedBodyCheck:  GreaterThanLength,
+				expectedBodyLength: 0,
+				expectedFields:     []string{"matched_ids"},
+			},
+			query:            "id=" + url.QueryEscape(sample["badge"].(string)),
+			sampleMatchedIDs: []string{"badge"},
+		},
+		{
+			genericTestOptions: genericTestOptions{
+				name:               "IdMatchesEmployeeId",
+				expectedStatus:     http.StatusOK,
+				expectedBodyCheck:  GreaterThanLength,
+				expectedBodyLength: 0,
+				expectedFields:     []string{"matched_ids"},
+			},
+			query:            "id=" + url.QueryEscape(sample["employee_id"].(string)),
+			sampleMatchedIDs: []string{"employee_id"},
+		},
+		{
+			genericTestOptions: genericTestOptions{
+				name:               "IdMatchesHelmetId",
+				expectedStatus:     http.StatusOK,
+				expectedBodyCheck:  GreaterThanLength,
+				expectedBodyLength: 0,
+				expectedFields:     []string{"matched_ids"},
+			},
+			query:            "id=" + url.QueryEscape(sample["helmet_id"].(string)),
+			sampleMatchedIDs: []string{"helmet_id"},
+		},
+		{
+			genericTestOptions: genericTestOptions{
+				name:               "IdMatchesNothing",
+				expectedStatus:     http.StatusOK,
+				expectedBodyCheck:  EqualsLength,
+				expectedBodyLength: 0,
+			},
+			query: "id=no-such-id",
+		},
+		{
+			genericTestOptions: genericTestOptions{
+				name:               "BadgeStrictSearch",
+				expectedStatus:     http.StatusOK,
+				expectedBodyCheck:  GreaterThanLength,
+				expectedBodyLength: 0,
+				expectedFirst:      map[string]interface{}{"badge_normalized": sample["badge_normalized"]},
+			},
+			query:            "badge=" + url.QueryEscape(sample["badge"].(string)),
+			sampleMatchedIDs: []string{},
+		},
+		{
+			genericTestOptions: genericTestOptions{
+				name:               "EmployeeIdStrictSearch",
+				expectedStatus:     http.StatusOK,
+				expectedBodyCheck:  GreaterThanLength,
+				expectedBodyLength: 0,
+				expectedFirst:      map[string]interface{}{"employee_id": sample["employee_id"]},
+			},
+			query:            "employee_id=" + url.QueryEscape(sample["employee_id"].(string)),
+			sampleMatchedIDs: []string{},
+		},
+		{
+			genericTestOptions: genericTestOptions{
+				name:               "HelmetIdStrictSearch",
+				expectedStatus:     http.StatusOK,
+				expectedBodyCheck:  GreaterThanLength,
+				expectedBodyLength: 0,
+				expectedFirst:      map[string]interface{}{"helmet_id": sample["helmet_id"]},
+			},
+			query:            "helmet_id=" + url.QueryEscape(sample["helmet_id"].(string)),
+			sampleMatchedIDs: []string{},
+		},
+		{
+			genericTestOptions: genericTestOptions{
+				name:               "HelmetIdThreeDigitStrictSearch",
+				expectedStatus:     http.StatusOK,
+				expectedBodyCheck:  GreaterThanLength,
+				expectedBodyLength: 0,
+				expectedFirst:      map[string]interface{}{"helmet_id_three_digit": sample["helmet_id_three_digit"]},
+			},
+			query:            "helmet_id_three_digit=" + url.QueryEscape(sample["helmet_id_three_digit"].(string)),
+			sampleMatchedIDs: []string{},
+		},
+		{
+			genericTestOptions: genericTestOptions{
+				name:               "FirstAndLastNameStrictSearch",
+				expectedStatus:     http.StatusOK,
+				expectedBodyCheck:  GreaterThanLength,
+				expectedBodyLength: 0,
+				expectedFields:     []string{"first_name", "last_name", "date", "is_current"},
+			},
+			query:            fmt.Sprintf("first_name=%s&last_name=%s&nicknames=false", url.QueryEscape(sample["first_name"].(string)), url.QueryEscape(sample["last_name"].(string))),
+			sampleMatchedIDs: []string{},
+		},
+		{
+			genericTestOptions: genericTestOptions{
+				name:               "FlagOnlyStrictSearch",
+				expectedStatus:     http.StatusOK,
+				expectedBodyCheck:  GreaterThanLength,
+				expectedBodyLength: 0,
+				expectedAll:        map[string]interface{}{"involved_in_ois_uof": true},
+			},
+			query: "involved_in_ois_uof=true",
+		},
+		{
+			genericTestOptions: genericTestOptions{
+				name:              "InvalidFlag",
+				expectedStatus:    http.StatusBadRequest,
+				expectedBody:      []byte("involved_in_ois_uof must be true or false"),
+				expectedBodyCheck: EqualsBytes,
+			},
+			query: "involved_in_ois_uof=maybe",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			res, _ := http.Get(fmt.Sprintf("%s/portland/officer?%s", testServer, tt.query))
+
+			if res.StatusCode != tt.expectedStatus {
+				t.Fatalf("\nTest: %s\nExpected status %d; got %d", tt.name, tt.expectedStatus, res.StatusCode)
+			}
+
+			defer res.Body.Close()
+			resp, _ := ioutil.ReadAll(res.Body)
+
+			checkBody(resp, tt.genericTestOptions, t)
+			if tt.sampleMatchedIDs != nil {
+				checkPortlandSample(resp, tt.name, sample, tt.sampleMatchedIDs, t)
+			}
+		})
+	}
+}
+
+// Test Portland historical endpoint
+func testPortlandHistorical(ctx context.Context, t *testing.T, profile string) {
+	t.Parallel()
+	sample := portlandSample(t)
+	for _, tt := range [...]genericTestOptions{
+		{
+			name:              "NoParams",
+			expectedStatus:    http.StatusBadRequest,
+			expectedBody:      []byte("at least one of the following parameters must be provided: badge"),
+			expectedBodyCheck: EqualsBytes,
+		},
+		{
+			name:               "BadgeHistoricalSearch",
+			badge:              sample["badge"].(string),
+			expectedStatus:     http.StatusOK,
+			expectedBodyCheck:  GreaterThanLength,
+			expectedBodyLength: 0,
+			expectedFirst:      map[string]interface{}{"badge_normalized": sample["badge_normalized"], "is_current": true},
+			expectedFields:     []string{"date", "is_current"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			res, _ := http.Get(fmt.Sprintf("%s/portland/officer/historical?badge=%s", testServer, url.QueryEscape(tt.badge)))
+
+			if res.StatusCode != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", tt.expectedStatus, res.StatusCode)
+			}
+
+			defer res.Body.Close()
+			resp, _ := ioutil.ReadAll(res.Body)
+
+			checkBody(resp, tt, t)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			// Entries are listed most recent first, and only the first may be the current roster
+			var entries []map[string]interface{}
+			err := json.Unmarshal(resp, &entries)
+			if err != nil {
+				t.Fatalf("Unexpected error unmarsheling JSON response: %v", err)
+			}
+			for i := 1; i < len(entries); i++ {
+				if fmt.Sprint(entries[i]["date"]) >= fmt.Sprint(entries[i-1]["date"]) {
+					t.Errorf("Expected entry %d dated before %v; got %v", i, entries[i-1]["date"], entries[i]["date"])
+				}
+				if entries[i]["is_current"] != false {
+					t.Errorf("Expected entry %d, dated %v, not to be current", i, entries[i]["date"])
+				}
+			}
+		})
+	}
+}
+
+// Test Portland RRT endpoint
+func testPortlandRRT(ctx context.Context, t *testing.T, profile string) {
+	t.Parallel()
+	for _, tt := range [...]struct {
+		genericTestOptions
+		query string
+		// recordedIn lists the columns at least one of which every officer listed must be
+		// recorded in
+		recordedIn []string
+	}{
+		{
+			genericTestOptions: genericTestOptions{
+				name:               "Members",
+				expectedStatus:     http.StatusOK,
+				expectedBodyCheck:  GreaterThanLength,
+				expectedBodyLength: 0,
+			},
+			recordedIn: []string{"rrt", "rrt_2016", "rrt_2018_niiya_email"},
+		},
+		{
+			genericTestOptions: genericTestOptions{
+				name:               "Members2016",
+				expectedStatus:     http.StatusOK,
+				expectedBodyCheck:  GreaterThanLength,
+				expectedBodyLength: 0,
+				expectedAll:        map[string]interface{}{"rrt_2016": true},
+			},
+			query: "year=2016",
+		},
+		{
+			genericTestOptions: genericTestOptions{
+				name:               "Members2018",
+				expectedStatus:     http.StatusOK,
+				expectedBodyCheck:  GreaterThanLength,
+				expectedBodyLength: 0,
+				expectedAll:        map[string]interface{}{"rrt_2018_niiya_email": true},
+			},
+			query: "year=2018",
+		},
+		{
+			genericTestOptions: genericTestOptions{
+				name:              "MembersNotRecorded",
+				expectedStatus:    http.StatusBadRequest,
+				expectedBody:      []byte("year must be one of the following: 2016, 2018"),
+				expectedBodyCheck: EqualsBytes,
+			},
+			query: "year=2019",
+		},
+		{
+			genericTestOptions: genericTestOptions{
+				name:               "Training2018",
+				expectedStatus:     http.StatusOK,
+				expectedBodyCheck:  GreaterThanLength,
+				expectedBodyLength: 0,
+				expectedAll:        map[string]interface{}{"rrt_2018": true},
+			},
+			query: "training=true&year=2018",
+		},
+		{
+			genericTestOptions: genericTestOptions{
+				name:               "Training",
+				expectedStatus:     http.StatusOK,
+				expectedBodyCheck:  GreaterThanLength,
+				expectedBodyLength: 0,
+			},
+			query:      "training=true",
+			recordedIn: []string{"rrt_2018", "rrt_2019", "rrt_2020"},
+		},
+		{
+			genericTestOptions: genericTestOptions{
+				name:               "Training2020",
+				expectedStatus:     http.StatusOK,
+				expectedBodyCheck:  GreaterThanLength,
+				expectedBodyLength: 0,
+				expectedAll:        map[string]interface{}{"rrt_2020": true},
+			},
+			query: "training=true&year=2020",
+		},
+		{
+			genericTestOptions: genericTestOptions{
+				name:              "TrainingNotRecorded",
+				expectedStatus:    http.StatusBadRequest,
+				expectedBody:      []byte("year must be one of the following: 2018, 2019, 2020"),
+				expectedBodyCheck: EqualsBytes,
+			},
+			query: "training=true&year=2016",
+		},
+		{
+			genericTestOptions: genericTestOptions{
+				name:              "InvalidTraining",
+				expectedStatus:    http.StatusBadRequest,
+				expectedBody:      []byte("training must be true or false"),
+				expectedBodyCheck: EqualsBytes,
+			},
+			query: "training=maybe",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			res, _ := http.Get(fmt.Sprintf("%s/portland/officer/rrt?%s", testServer, tt.query))
+
+			if res.StatusCode != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", tt.expectedStatus, res.StatusCode)
+			}
+
+			defer res.Body.Close()
+			resp, _ := ioutil.ReadAll(res.Body)
+
+			checkBody(resp, tt.genericTestOptions, t)
+			if len(tt.recordedIn) == 0 {
+				return
+			}
+
+			var officers []map[string]interface{}
+			err := json.Unmarshal(resp, &officers)
+			if err != nil {
+				t.Fatalf("Unexpected error unmarsheling JSON response: %v", err)
+			}
+			for i, officer := range officers {
+				recorded := false
+				for _, column := range tt.recordedIn {
+					recorded = recorded || officer[column] == true
+				}
+				if !recorded {
+					t.Errorf("Expected officer %d to be recorded in one of %v; got %v", i, tt.recordedIn, officer)
+				}
+			}
+		})
+	}
+}
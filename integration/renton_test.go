@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"testing"
 )
 
@@ -15,7 +16,7 @@ func testRentonStrict(ctx context.Context, t *testing.T, profile string) {
 		{
 			name:              "NoParams",
 			expectedStatus:    http.StatusBadRequest,
-			expectedBody:      []byte("at least one of the following parameters must be provided: first_name, last_name"),
+			expectedBody:      []byte("at least one of the following parameters must be provided: badge, first_name, middle_name, last_name, full_name"),
 			expectedBodyCheck: EqualsBytes,
 		},
 		{
@@ -40,9 +41,17 @@ func testRentonStrict(ctx context.Context, t *testing.T, profile string) {
 			expectedBodyCheck:  EqualsLength,
 			expectedBodyLength: 1,
 		},
+		{
+			name:               "FullNameStrictSearch",
+			fullName:           "Augustine*Abram",
+			expectedStatus:     http.StatusOK,
+			expectedBodyCheck:  EqualsLength,
+			expectedBodyLength: 1,
+			expectedFirst:      map[string]interface{}{"first_name": "Augustine", "last_name": "Abram"},
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
-			res, _ := http.Get(fmt.Sprintf("%s/renton/officer?first_name=%s&last_name=%s", testServer, tt.firstName, tt.lastName))
+			res, _ := http.Get(fmt.Sprintf("%s/renton/officer?first_name=%s&last_name=%s&full_name=%s", testServer, tt.firstName, tt.lastName, url.QueryEscape(tt.fullName)))
 
 			if res.StatusCode != tt.expectedStatus {
 				t.Fatalf("Expected status %d, got %d", tt.expectedStatus, res.StatusCode)
@@ -63,7 +72,7 @@ func testRentonFuzzy(ctx context.Context, t *testing.T, profile string) {
 		{
 			name:              "NoParams",
 			expectedStatus:    http.StatusBadRequest,
-			expectedBody:      []byte("at least one of the following parameters must be provided: first_name, last_name"),
+			expectedBody:      []byte("at least one of the following parameters must be provided: first_name, last_name, full_name"),
 			expectedBodyCheck: EqualsBytes,
 		},
 		{
@@ -88,9 +97,16 @@ func testRentonFuzzy(ctx context.Context, t *testing.T, profile string) {
 			expectedBodyCheck:  EqualsLength,
 			expectedBodyLength: 1,
 		},
+		{
+			name:               "FullNameFuzzySearch",
+			fullName:           "Augustine Abram",
+			expectedStatus:     http.StatusOK,
+			expectedBodyCheck:  GreaterThanLength,
+			expectedBodyLength: 0,
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
-			res, _ := http.Get(fmt.Sprintf("%s/renton/officer/search?first_name=%s&last_name=%s", testServer, tt.firstName, tt.lastName))
+			res, _ := http.Get(fmt.Sprintf("%s/renton/officer/search?first_name=%s&last_name=%s&full_name=%s", testServer, tt.firstName, tt.lastName, url.QueryEscape(tt.fullName)))
 
 			if res.StatusCode != tt.expectedStatus {
 				t.Fatalf("Expected status %d, got %d", tt.expectedStatus, res.StatusCode)
@@ -2,9 +2,11 @@ package integration
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"testing"
 )
 
@@ -15,7 +17,7 @@ func testSeattleStrict(ctx context.Context, t *testing.T, profile string) {
 		{
 			name:              "NoParams",
 			expectedStatus:    http.StatusBadRequest,
-			expectedBody:      []byte("at least one of the following parameters must be provided: badge, first_name, last_name"),
+			expectedBody:      []byte("at least one of the following parameters must be provided: badge, first_name, middle_name, last_name, full_name"),
 			expectedBodyCheck: EqualsBytes,
 		},
 		{
@@ -24,6 +26,40 @@ func testSeattleStrict(ctx context.Context, t *testing.T, profile string) {
 			expectedStatus:     http.StatusOK,
 			expectedBodyCheck:  EqualsLength,
 			expectedBodyLength: 1,
+			expectedFirst:      map[string]interface{}{"badge_normalized": "5669"},
+		},
+		{
+			name:               "NormalizedBadgeStrictSearch",
+			badge:              "# 05669",
+			expectedStatus:     http.StatusOK,
+			expectedBodyCheck:  EqualsLength,
+			expectedBodyLength: 1,
+			expectedFirst:      map[string]interface{}{"badge_normalized": "5669"},
+		},
+		{
+			name:              "InvalidBadgeMode",
+			badge:             "5669",
+			badgeMode:         "fuzzy",
+			expectedStatus:    http.StatusBadRequest,
+			expectedBody:      []byte("badge_mode must be one of the following: exact, partial, confusable"),
+			expectedBodyCheck: EqualsBytes,
+		},
+		{
+			name:               "PartialBadgeStrictSearch",
+			badge:              "56?9",
+			badgeMode:          "partial",
+			expectedStatus:     http.StatusOK,
+			expectedBodyCheck:  GreaterThanLength,
+			expectedBodyLength: 0,
+			expectedFields:     []string{"badge"},
+		},
+		{
+			name:               "ConfusableBadgeStrictSearch",
+			badge:              "S669",
+			badgeMode:          "confusable",
+			expectedStatus:     http.StatusOK,
+			expectedBodyCheck:  GreaterThanLength,
+			expectedBodyLength: 0,
 		},
 		{
 			name:               "FirstNameStrictSearch",
@@ -47,9 +83,142 @@ func testSeattleStrict(ctx context.Context, t *testing.T, profile string) {
 			expectedBodyCheck:  EqualsLength,
 			expectedBodyLength: 1,
 		},
+		{
+			name:              "InvalidRank",
+			lastName:          "Kelly",
+			rank:              "general",
+			expectedStatus:    http.StatusBadRequest,
+			expectedBody:      []byte("rank must be one of the following: officer, detective, sergeant, lieutenant, captain, command, civilian"),
+			expectedBodyCheck: EqualsBytes,
+		},
+		{
+			name:               "RankFilteredStrictSearch",
+			badge:              "5669",
+			rank:               "civilian",
+			expectedStatus:     http.StatusOK,
+			expectedBodyCheck:  EqualsLength,
+			expectedBodyLength: 0,
+		},
+		{
+			name:               "TitleFilteredStrictSearch",
+			firstName:          "James",
+			lastName:           "Kelly",
+			title:              "*",
+			expectedStatus:     http.StatusOK,
+			expectedBodyCheck:  EqualsLength,
+			expectedBodyLength: 1,
+			expectedFirst:      map[string]interface{}{"first_name": "James", "last_name": "Kelly"},
+			expectedFields:     []string{"title"},
+		},
+		{
+			name:               "TitleFilterExcludesStrictSearch",
+			lastName:           "Kelly",
+			title:              "*no such title*",
+			expectedStatus:     http.StatusOK,
+			expectedBodyCheck:  EqualsLength,
+			expectedBodyLength: 0,
+		},
+		{
+			name:               "SingleCharacterWildcardStrictSearch",
+			lastName:           "Kell?",
+			expectedStatus:     http.StatusOK,
+			expectedBodyCheck:  GreaterThanLength,
+			expectedBodyLength: 0,
+		},
+		{
+			name:               "LiteralUnderscoreStrictSearch",
+			lastName:           "Kel_y",
+			expectedStatus:     http.StatusOK,
+			expectedBodyCheck:  EqualsLength,
+			expectedBodyLength: 0,
+		},
+		{
+			name:               "RegexStrictSearch",
+			lastName:           "^kel+y$",
+			mode:               "regex",
+			expectedStatus:     http.StatusOK,
+			expectedBodyCheck:  GreaterThanLength,
+			expectedBodyLength: 0,
+			expectedFirst:      map[string]interface{}{"last_name": "Kelly"},
+		},
+		{
+			name:              "ComplexRegexStrictSearch",
+			lastName:          "(k+)+",
+			mode:              "regex",
+			expectedStatus:    http.StatusBadRequest,
+			expectedBody:      []byte("last_name is too complex a regular expression: nested repetition, repetition counts above 20 and more than 50 terms are not supported"),
+			expectedBodyCheck: EqualsBytes,
+		},
+		{
+			name:               "FreeTextNameStrictSearch",
+			q:                  "Kelly, James",
+			expectedStatus:     http.StatusOK,
+			expectedBodyCheck:  EqualsLength,
+			expectedBodyLength: 1,
+			expectedFirst:      map[string]interface{}{"first_name": "James", "last_name": "Kelly"},
+		},
+		{
+			name:               "FreeTextBadgeStrictSearch",
+			q:                  "#5669",
+			expectedStatus:     http.StatusOK,
+			expectedBodyCheck:  EqualsLength,
+			expectedBodyLength: 1,
+			expectedFirst:      map[string]interface{}{"badge_normalized": "5669"},
+		},
+		{
+			name:               "NicknameStrictSearch",
+			firstName:          "Jim",
+			lastName:           "Kelly",
+			expectedStatus:     http.StatusOK,
+			expectedBodyCheck:  GreaterThanLength,
+			expectedBodyLength: 0,
+			expectedFirst:      map[string]interface{}{"last_name": "Kelly"},
+		},
+		{
+			name:              "InvalidNicknames",
+			firstName:         "Jim",
+			nicknames:         "maybe",
+			expectedStatus:    http.StatusBadRequest,
+			expectedBody:      []byte("nicknames must be one of the following: true, false"),
+			expectedBodyCheck: EqualsBytes,
+		},
+		{
+			name:               "MiddleInitialStrictSearch",
+			middleName:         "J",
+			expectedStatus:     http.StatusOK,
+			expectedBodyCheck:  GreaterThanLength,
+			expectedBodyLength: 1,
+			expectedFields:     []string{"middle_name"},
+		},
+		{
+			name:               "DiacriticInsensitiveStrictSearch",
+			firstName:          "Jámes",
+			lastName:           "Kélly",
+			expectedStatus:     http.StatusOK,
+			expectedBodyCheck:  EqualsLength,
+			expectedBodyLength: 1,
+			expectedFirst:      map[string]interface{}{"first_name": "James", "last_name": "Kelly"},
+		},
+		{
+			name:               "PunctuationInsensitiveStrictSearch",
+			firstName:          "James",
+			lastName:           "Kel'ly",
+			expectedStatus:     http.StatusOK,
+			expectedBodyCheck:  EqualsLength,
+			expectedBodyLength: 1,
+			expectedFirst:      map[string]interface{}{"first_name": "James", "last_name": "Kelly"},
+		},
+		{
+			name:               "FullNameStrictSearch",
+			fullName:           "*Kelly*",
+			expectedStatus:     http.StatusOK,
+			expectedBodyCheck:  GreaterThanLength,
+			expectedBodyLength: 1,
+			expectedFields:     []string{"full_name"},
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
-			res, _ := http.Get(fmt.Sprintf("%s/seattle/officer?badge=%s&first_name=%s&last_name=%s", testServer, tt.badge, tt.firstName, tt.lastName))
+			res, _ := http.Get(fmt.Sprintf("%s/seattle/officer?badge=%s&badge_mode=%s&first_name=%s&middle_name=%s&last_name=%s&full_name=%s&title=%s&rank=%s&nicknames=%s&mode=%s&q=%s", testServer, url.QueryEscape(tt.badge), tt.badgeMode, url.QueryEscape(tt.firstName), url.QueryEscape(tt.middleName), url.QueryEscape(tt.lastName), url.QueryEscape(tt.fullName), url.QueryEscape(tt.title), tt.rank, tt.nicknames, tt.mode, url.QueryEscape(tt.q)))
 
 			if res.StatusCode != tt.expectedStatus {
 				t.Fatalf("Expected status %d, got %d", tt.expectedStatus, res.StatusCode)
@@ -70,7 +239,7 @@ func testSeattleFuzzy(ctx context.Context, t *testing.T, profile string) {
 		{
 			name:              "NoParams",
 			expectedStatus:    http.StatusBadRequest,
-			expectedBody:      []byte("at least one of the following parameters must be provided: first_name, last_name"),
+			expectedBody:      []byte("at least one of the following parameters must be provided: first_name, last_name, full_name"),
 			expectedBodyCheck: EqualsBytes,
 		},
 		{
@@ -79,6 +248,8 @@ func testSeattleFuzzy(ctx context.Context, t *testing.T, profile string) {
 			expectedStatus:     http.StatusOK,
 			expectedBodyCheck:  GreaterThanLength,
 			expectedBodyLength: 1,
+			expectedFirst:      map[string]interface{}{"first_name": "James", "match_score": 1.0, "matched_on": "first_name"},
+			expectedFields:     []string{"match_score", "matched_on"},
 		},
 		{
 			name:               "LastNameStrictSearch",
@@ -86,6 +257,8 @@ func testSeattleFuzzy(ctx context.Context, t *testing.T, profile string) {
 			expectedStatus:     http.StatusOK,
 			expectedBodyCheck:  GreaterThanLength,
 			expectedBodyLength: 1,
+			expectedFirst:      map[string]interface{}{"last_name": "Kelly", "match_score": 1.0, "matched_on": "last_name"},
+			expectedFields:     []string{"match_score", "matched_on"},
 		},
 		{
 			name:               "FirstAndLastNameStrictSearch",
@@ -95,9 +268,101 @@ func testSeattleFuzzy(ctx context.Context, t *testing.T, profile string) {
 			expectedBodyCheck:  GreaterThanLength,
 			expectedBodyLength: 1,
 		},
+		{
+			name:              "InvalidMode",
+			lastName:          "Kelly",
+			mode:              "soundex",
+			expectedStatus:    http.StatusBadRequest,
+			expectedBody:      []byte("mode must be one of the following: trigram, phonetic"),
+			expectedBodyCheck: EqualsBytes,
+		},
+		{
+			name:               "NicknameFirstNameSearch",
+			firstName:          "Jim",
+			lastName:           "Kelly",
+			expectedStatus:     http.StatusOK,
+			expectedBodyCheck:  GreaterThanLength,
+			expectedBodyLength: 0,
+			expectedFirst:      map[string]interface{}{"first_name": "James", "last_name": "Kelly", "match_score": 1.0, "matched_on": "nickname", "matched_nickname": "james"},
+		},
+		{
+			name:              "InvalidMinScore",
+			lastName:          "Kelly",
+			minScore:          "2",
+			expectedStatus:    http.StatusBadRequest,
+			expectedBody:      []byte("min_score must be a number between 0 and 1"),
+			expectedBodyCheck: EqualsBytes,
+		},
+		{
+			name:               "MinScoreLastNameSearch",
+			lastName:           "Kelly",
+			minScore:           "1",
+			expectedStatus:     http.StatusOK,
+			expectedBodyCheck:  GreaterThanLength,
+			expectedBodyLength: 0,
+			expectedFirst:      map[string]interface{}{"last_name": "Kelly", "match_score": 1.0},
+		},
+		{
+			name:              "InvalidSort",
+			lastName:          "Kelly",
+			sort:              "alphabetical",
+			expectedStatus:    http.StatusBadRequest,
+			expectedBody:      []byte("sort must be one of the following: relevance, recency"),
+			expectedBodyCheck: EqualsBytes,
+		},
+		{
+			name:               "RecencySortedLastNameSearch",
+			lastName:           "Kelly",
+			sort:               "recency",
+			expectedStatus:     http.StatusOK,
+			expectedBodyCheck:  GreaterThanLength,
+			expectedBodyLength: 1,
+		},
+		{
+			name:              "InvalidThreshold",
+			lastName:          "Kelly",
+			threshold:         "loose",
+			expectedStatus:    http.StatusBadRequest,
+			expectedBody:      []byte("threshold must be a number greater than 0 and at most 1"),
+			expectedBodyCheck: EqualsBytes,
+		},
+		{
+			name:               "LowThresholdLastNameSearch",
+			lastName:           "Kely",
+			threshold:          "0.1",
+			expectedStatus:     http.StatusOK,
+			expectedBodyCheck:  GreaterThanLength,
+			expectedBodyLength: 1,
+			expectedFirst:      map[string]interface{}{"last_name": "Kelly"},
+		},
+		{
+			name:               "PhoneticLastNameSearch",
+			lastName:           "Kely",
+			mode:               "phonetic",
+			expectedStatus:     http.StatusOK,
+			expectedBodyCheck:  GreaterThanLength,
+			expectedBodyLength: 1,
+			expectedFirst:      map[string]interface{}{"last_name": "Kelly", "matched_on": "last_name"},
+		},
+		{
+			name:               "DiacriticInsensitiveFuzzySearch",
+			lastName:           "Kélly",
+			expectedStatus:     http.StatusOK,
+			expectedBodyCheck:  GreaterThanLength,
+			expectedBodyLength: 1,
+			expectedFirst:      map[string]interface{}{"last_name": "Kelly"},
+		},
+		{
+			name:               "FullNameFuzzySearch",
+			fullName:           "James Kelly",
+			expectedStatus:     http.StatusOK,
+			expectedBodyCheck:  GreaterThanLength,
+			expectedBodyLength: 0,
+			expectedFirst:      map[string]interface{}{"first_name": "James", "last_name": "Kelly"},
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
-			res, _ := http.Get(fmt.Sprintf("%s/seattle/officer/search?first_name=%s&last_name=%s", testServer, tt.firstName, tt.lastName))
+			res, _ := http.Get(fmt.Sprintf("%s/seattle/officer/search?first_name=%s&middle_name=%s&last_name=%s&full_name=%s&mode=%s&nicknames=%s&min_score=%s&sort=%s&threshold=%s", testServer, url.QueryEscape(tt.firstName), url.QueryEscape(tt.middleName), url.QueryEscape(tt.lastName), url.QueryEscape(tt.fullName), tt.mode, tt.nicknames, tt.minScore, tt.sort, tt.threshold))
 
 			if res.StatusCode != tt.expectedStatus {
 				t.Fatalf("Expected status %d, got %d", tt.expectedStatus, res.StatusCode)
@@ -143,3 +408,98 @@ func testSeattleHistorical(ctx context.Context, t *testing.T, profile string) {
 		})
 	}
 }
+
+// Test Seattle precincts endpoint
+func testSeattlePrecincts(ctx context.Context, t *testing.T, profile string) {
+	t.Parallel()
+	res, err := http.Get(fmt.Sprintf("%s/seattle/precincts", testServer))
+	if err != nil {
+		t.Fatalf("Unspecified error with request: %v", err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, res.StatusCode)
+	}
+	if contentType := res.Header.Get("Content-Type"); contentType != "application/geo+json" {
+		t.Errorf("Expected content type application/geo+json, got %s", contentType)
+	}
+
+	defer res.Body.Close()
+	var precincts struct {
+		Type     string `json:"type"`
+		Features []struct {
+			Properties struct {
+				Name      string `json:"name"`
+				Headcount int    `json:"headcount"`
+			} `json:"properties"`
+		} `json:"features"`
+	}
+	err = json.NewDecoder(res.Body).Decode(&precincts)
+	if err != nil {
+		t.Fatalf("Unexpected error unmarsheling JSON response: %v", err)
+	}
+
+	if precincts.Type != "FeatureCollection" {
+		t.Errorf("Expected type FeatureCollection, got %s", precincts.Type)
+	}
+	if len(precincts.Features) != 5 {
+		t.Errorf("Expected 5 precincts, got %d", len(precincts.Features))
+	}
+	for _, feature := range precincts.Features {
+		if feature.Properties.Headcount < 1 {
+			t.Errorf("Expected officers assigned to %s precinct, got %d", feature.Properties.Name, feature.Properties.Headcount)
+		}
+	}
+}
+
+// Test Seattle promotions report endpoint
+func testSeattlePromotions(ctx context.Context, t *testing.T, profile string) {
+	t.Parallel()
+	for _, tt := range [...]struct {
+		genericTestOptions
+		from string
+		to   string
+	}{
+		{
+			genericTestOptions: genericTestOptions{
+				name:              "InvalidDate",
+				expectedStatus:    http.StatusBadRequest,
+				expectedBody:      []byte("from and to must be dates in the format YYYY-MM-DD"),
+				expectedBodyCheck: EqualsBytes,
+			},
+			from: "12/01/2020",
+		},
+		{
+			genericTestOptions: genericTestOptions{
+				name:               "AllRosters",
+				expectedStatus:     http.StatusOK,
+				expectedBodyCheck:  GreaterThanLength,
+				expectedBodyLength: 0,
+				expectedFields:     []string{"date", "previous_date", "previous_title"},
+			},
+		},
+		{
+			genericTestOptions: genericTestOptions{
+				name:               "EmptyWindow",
+				expectedStatus:     http.StatusOK,
+				expectedBodyCheck:  EqualsLength,
+				expectedBodyLength: 0,
+			},
+			from: "1990-01-01",
+			to:   "1990-12-31",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			res, _ := http.Get(fmt.Sprintf("%s/seattle/reports/promotions?from=%s&to=%s", testServer, tt.from, tt.to))
+
+			if res.StatusCode != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", tt.expectedStatus, res.StatusCode)
+			}
+
+			defer res.Body.Close()
+			resp, _ := ioutil.ReadAll(res.Body)
+
+			checkBody(resp, tt.genericTestOptions, t)
+		})
+	}
+}
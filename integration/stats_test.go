@@ -0,0 +1,195 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+// Test headcount endpoints for each department with dated rosters
+func testHeadcount(ctx context.Context, t *testing.T, profile string) {
+	t.Parallel()
+	for _, tt := range [...]struct {
+		genericTestOptions
+		department string
+		groupBy    string
+	}{
+		{
+			genericTestOptions: genericTestOptions{
+				name:               "SeattleTotal",
+				expectedStatus:     http.StatusOK,
+				expectedBodyCheck:  GreaterThanLength,
+				expectedBodyLength: 1,
+				expectedFields:     []string{"date", "count"},
+			},
+			department: "seattle",
+		},
+		{
+			genericTestOptions: genericTestOptions{
+				name:               "SeattleByUnit",
+				expectedStatus:     http.StatusOK,
+				expectedBodyCheck:  GreaterThanLength,
+				expectedBodyLength: 1,
+				expectedFields:     []string{"date", "count"},
+			},
+			department: "seattle",
+			groupBy:    "unit",
+		},
+		{
+			genericTestOptions: genericTestOptions{
+				name:              "SeattleInvalidGroup",
+				expectedStatus:    http.StatusBadRequest,
+				expectedBody:      []byte("group_by must be one of the following: title, unit"),
+				expectedBodyCheck: EqualsBytes,
+			},
+			department: "seattle",
+			groupBy:    "badge",
+		},
+		{
+			genericTestOptions: genericTestOptions{
+				name:               "TacomaByTitle",
+				expectedStatus:     http.StatusOK,
+				expectedBodyCheck:  GreaterThanLength,
+				expectedBodyLength: 1,
+				expectedFields:     []string{"date", "count"},
+			},
+			department: "tacoma",
+			groupBy:    "title",
+		},
+		{
+			genericTestOptions: genericTestOptions{
+				name:              "TacomaInvalidGroup",
+				expectedStatus:    http.StatusBadRequest,
+				expectedBody:      []byte("group_by must be one of the following: title"),
+				expectedBodyCheck: EqualsBytes,
+			},
+			department: "tacoma",
+			groupBy:    "unit",
+		},
+		{
+			genericTestOptions: genericTestOptions{
+				name:               "PortlandByTitle",
+				expectedStatus:     http.StatusOK,
+				expectedBodyCheck:  GreaterThanLength,
+				expectedBodyLength: 1,
+				expectedFields:     []string{"date", "count"},
+			},
+			department: "portland",
+			groupBy:    "title",
+		},
+		{
+			genericTestOptions: genericTestOptions{
+				name:               "AuburnByTitle",
+				expectedStatus:     http.StatusOK,
+				expectedBodyCheck:  GreaterThanLength,
+				expectedBodyLength: 1,
+				expectedFields:     []string{"date", "count"},
+			},
+			department: "auburn",
+			groupBy:    "title",
+		},
+		{
+			genericTestOptions: genericTestOptions{
+				name:               "LakewoodByUnit",
+				expectedStatus:     http.StatusOK,
+				expectedBodyCheck:  GreaterThanLength,
+				expectedBodyLength: 1,
+				expectedFields:     []string{"date", "count"},
+			},
+			department: "lakewood",
+			groupBy:    "unit",
+		},
+		{
+			genericTestOptions: genericTestOptions{
+				name:               "OlympiaByTitle",
+				expectedStatus:     http.StatusOK,
+				expectedBodyCheck:  GreaterThanLength,
+				expectedBodyLength: 1,
+				expectedFields:     []string{"date", "count"},
+			},
+			department: "olympia",
+			groupBy:    "title",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			res, _ := http.Get(fmt.Sprintf("%s/%s/stats/headcount?group_by=%s", testServer, tt.department, tt.groupBy))
+
+			if res.StatusCode != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", tt.expectedStatus, res.StatusCode)
+			}
+
+			defer res.Body.Close()
+			resp, _ := ioutil.ReadAll(res.Body)
+
+			checkBody(resp, tt.genericTestOptions, t)
+		})
+	}
+}
+
+// Test salary endpoints for each department with recorded salaries
+func testSalary(ctx context.Context, t *testing.T, profile string) {
+	t.Parallel()
+	for _, tt := range [...]struct {
+		genericTestOptions
+		department string
+		groupBy    string
+	}{
+		{
+			genericTestOptions: genericTestOptions{
+				name:               "TacomaTotal",
+				expectedStatus:     http.StatusOK,
+				expectedBodyCheck:  EqualsLength,
+				expectedBodyLength: 1,
+				expectedFields:     []string{"count", "min", "max", "mean", "median", "p25", "p75", "p90"},
+			},
+			department: "tacoma",
+		},
+		{
+			genericTestOptions: genericTestOptions{
+				name:               "TacomaByTitle",
+				expectedStatus:     http.StatusOK,
+				expectedBodyCheck:  GreaterThanLength,
+				expectedBodyLength: 1,
+				expectedFields:     []string{"count", "min", "max", "mean", "median", "p25", "p75", "p90"},
+			},
+			department: "tacoma",
+			groupBy:    "title",
+		},
+		{
+			genericTestOptions: genericTestOptions{
+				name:              "TacomaInvalidGroup",
+				expectedStatus:    http.StatusBadRequest,
+				expectedBody:      []byte("group_by must be one of the following: title, department"),
+				expectedBodyCheck: EqualsBytes,
+			},
+			department: "tacoma",
+			groupBy:    "unit",
+		},
+		{
+			genericTestOptions: genericTestOptions{
+				name:               "PortlandByTitle",
+				expectedStatus:     http.StatusOK,
+				expectedBodyCheck:  GreaterThanLength,
+				expectedBodyLength: 1,
+				expectedFields:     []string{"count", "min", "max", "mean", "median", "p25", "p75", "p90"},
+			},
+			department: "portland",
+			groupBy:    "title",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			res, _ := http.Get(fmt.Sprintf("%s/%s/stats/salary?group_by=%s", testServer, tt.department, tt.groupBy))
+
+			if res.StatusCode != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", tt.expectedStatus, res.StatusCode)
+			}
+
+			defer res.Body.Close()
+			resp, _ := ioutil.ReadAll(res.Body)
+
+			checkBody(resp, tt.genericTestOptions, t)
+		})
+	}
+}
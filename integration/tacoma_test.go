@@ -15,7 +15,7 @@ func testTacomaStrict(ctx context.Context, t *testing.T, profile string) {
 		{
 			name:              "NoParams",
 			expectedStatus:    http.StatusBadRequest,
-			expectedBody:      []byte("at least one of the following parameters must be provided: first_name, last_name"),
+			expectedBody:      []byte("at least one of the following parameters must be provided: first_name, last_name, salary_min, salary_max"),
 			expectedBodyCheck: EqualsBytes,
 		},
 		{